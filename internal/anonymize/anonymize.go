@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package anonymize replaces the identifiers, URLs and names contained in the objects with pseudonyms, so that the
+// output of the tool can be shared without revealing details of the environment. The pseudonyms are derived from the
+// original values and a seed, so the same value always gets the same pseudonym, and references between objects, like
+// the identifier of the cluster of an order, are preserved.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/innabox/fulfillment-cli/internal/output"
+)
+
+// Flags contains the values of the flags that control the anonymization.
+type Flags struct {
+	Enabled bool
+	Seed    string
+}
+
+// AddFlags adds the '--anonymize' and '--seed' flags to the given flag set.
+func AddFlags(flags *pflag.FlagSet) *Flags {
+	result := &Flags{}
+	flags.BoolVar(
+		&result.Enabled,
+		"anonymize",
+		false,
+		"Replace identifiers, URLs and names with pseudonyms, so that the output can be shared without revealing "+
+			"details of the environment",
+	)
+	flags.StringVar(
+		&result.Seed,
+		"seed",
+		"",
+		"Seed used to calculate the pseudonyms of '--anonymize'. The same seed always produces the same "+
+			"pseudonyms. By default a random seed is used for each execution.",
+	)
+	return result
+}
+
+// Apply checks the flags and, if anonymization was requested, registers the output processor that replaces the
+// values.
+func (f *Flags) Apply() error {
+	if !f.Enabled {
+		if f.Seed != "" {
+			return fmt.Errorf("flag '--seed' requires the '--anonymize' flag")
+		}
+		return nil
+	}
+	seed := []byte(f.Seed)
+	if len(seed) == 0 {
+		seed = make([]byte, 32)
+		_, err := rand.Read(seed)
+		if err != nil {
+			return fmt.Errorf("failed to generate seed: %w", err)
+		}
+	}
+	output.RegisterProcessor("anonymize", &processor{
+		seed: seed,
+	})
+	return nil
+}
+
+// processor is the output processor that replaces the values.
+type processor struct {
+	seed []byte
+}
+
+// Process is the implementation of the output.Processor interface.
+func (p *processor) Process(object proto.Message) error {
+	return p.message(object.ProtoReflect(), false)
+}
+
+// message replaces the values of the fields of the given message. When the sensitive flag is true all the strings
+// are replaced, regardless of the name of the field. That is used for the content of 'Any' fields, like the values of
+// the template parameters, as they may contain secrets.
+func (p *processor) message(message protoreflect.Message, sensitive bool) error {
+	if wrapper, ok := message.Interface().(*anypb.Any); ok {
+		return p.any(wrapper)
+	}
+
+	// Collect the populated fields first, as the message shouldn't be modified while iterating them:
+	var fields []protoreflect.FieldDescriptor
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		fields = append(fields, field)
+		return true
+	})
+	for _, field := range fields {
+		var value protoreflect.Value
+		if field.IsMap() || field.IsList() || field.Message() != nil {
+			value = message.Mutable(field)
+		} else {
+			value = message.Get(field)
+		}
+		switch {
+		case field.IsMap():
+			err := p.mapValues(field, value.Map(), sensitive)
+			if err != nil {
+				return err
+			}
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				switch field.Kind() {
+				case protoreflect.MessageKind, protoreflect.GroupKind:
+					err := p.message(list.Get(i).Message(), sensitive)
+					if err != nil {
+						return err
+					}
+				case protoreflect.StringKind:
+					list.Set(i, protoreflect.ValueOfString(p.value(field, list.Get(i).String(), sensitive)))
+				}
+			}
+		case field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind:
+			err := p.message(value.Message(), sensitive)
+			if err != nil {
+				return err
+			}
+		case field.Kind() == protoreflect.StringKind:
+			message.Set(field, protoreflect.ValueOfString(p.value(field, value.String(), sensitive)))
+		}
+	}
+	return nil
+}
+
+// mapValues replaces the values of a map field. The keys are preserved, as they are usually names defined in the
+// templates, like the names of the parameters.
+func (p *processor) mapValues(field protoreflect.FieldDescriptor, entries protoreflect.Map, sensitive bool) error {
+	var keys []protoreflect.MapKey
+	entries.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	for _, key := range keys {
+		value := entries.Get(key)
+		switch field.MapValue().Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			err := p.message(value.Message(), sensitive)
+			if err != nil {
+				return err
+			}
+		case protoreflect.StringKind:
+			entries.Set(key, protoreflect.ValueOfString(p.value(field, value.String(), sensitive)))
+		}
+	}
+	return nil
+}
+
+// any replaces the strings contained in an 'Any' message. Messages of types that aren't known are removed, as there
+// is no way to know what they contain.
+func (p *processor) any(wrapper *anypb.Any) error {
+	content, err := wrapper.UnmarshalNew()
+	if err != nil {
+		wrapper.Value = nil
+		return nil
+	}
+	err = p.message(content.ProtoReflect(), true)
+	if err != nil {
+		return err
+	}
+	return wrapper.MarshalFrom(content)
+}
+
+// value calculates the replacement of a string value, according to the name of the field that contains it. Note that
+// fields named just 'name', like the names of the template parameters, are preserved, because they are also used as
+// the keys of maps, and those aren't replaced.
+func (p *processor) value(field protoreflect.FieldDescriptor, value string, sensitive bool) string {
+	if value == "" {
+		return value
+	}
+	name := string(field.Name())
+	switch {
+	case strings.HasSuffix(name, "_url") || isURL(value):
+		return p.url(value)
+	case name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_ids"):
+		return "id-" + p.hash(value)
+	case name == "title" || name == "description" || strings.HasSuffix(name, "_name"):
+		return name + "-" + p.hash(value)
+	case sensitive:
+		return "value-" + p.hash(value)
+	default:
+		return value
+	}
+}
+
+// url replaces the host of the given URL, preserving the scheme, the port and the path, as those are useful for
+// diagnostics and don't usually reveal details of the environment.
+func (p *processor) url(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return "url-" + p.hash(value)
+	}
+	host := parsed.Hostname()
+	port := parsed.Port()
+	host = "host-" + p.hash(host) + ".example.com"
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+	parsed.Host = host
+	parsed.User = nil
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+// hash calculates a short keyed hash of the value.
+func (p *processor) hash(value string) string {
+	mac := hmac.New(sha256.New, p.seed)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:8]
+}
+
+func isURL(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
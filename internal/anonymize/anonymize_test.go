@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package anonymize
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+)
+
+func TestProcess(t *testing.T) {
+	secret, err := anypb.New(wrapperspb.String("my-pull-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := &fulfillmentv1.ClusterOrder{
+		Id: "123",
+		Spec: &fulfillmentv1.ClusterOrderSpec{
+			TemplateId: "ocp-small",
+			TemplateParameters: map[string]*anypb.Any{
+				"pull_secret": secret,
+			},
+		},
+		Status: &fulfillmentv1.ClusterOrderStatus{
+			ClusterId: "456",
+		},
+	}
+	cluster := &fulfillmentv1.Cluster{
+		Id: "456",
+		Status: &fulfillmentv1.ClusterStatus{
+			ApiUrl: "https://api.secret.example.org:6443/path?token=abc",
+		},
+	}
+	anonymizer := &processor{seed: []byte("seed")}
+	err = anonymizer.Process(order)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = anonymizer.Process(cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Identifiers are replaced, preserving the references between objects:
+	if order.Id == "123" || !strings.HasPrefix(order.Id, "id-") {
+		t.Fatalf("order identifier wasn't replaced: %s", order.Id)
+	}
+	if order.Spec.TemplateId == "ocp-small" {
+		t.Fatalf("template identifier wasn't replaced")
+	}
+	if order.Status.ClusterId != cluster.Id {
+		t.Fatalf("reference '%s' doesn't match cluster identifier '%s'", order.Status.ClusterId, cluster.Id)
+	}
+
+	// The host of URLs is replaced, preserving the scheme, port and path, but not the query:
+	url := cluster.Status.ApiUrl
+	if strings.Contains(url, "secret") || strings.Contains(url, "token") {
+		t.Fatalf("URL wasn't anonymized: %s", url)
+	}
+	if !strings.HasPrefix(url, "https://host-") || !strings.HasSuffix(url, ".example.com:6443/path") {
+		t.Fatalf("URL doesn't preserve scheme, port and path: %s", url)
+	}
+
+	// The keys of the template parameters are preserved, but the values are replaced:
+	value, ok := order.Spec.TemplateParameters["pull_secret"]
+	if !ok {
+		t.Fatalf("parameter name wasn't preserved")
+	}
+	content := &wrapperspb.StringValue{}
+	err = value.UnmarshalTo(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Value == "my-pull-secret" || !strings.HasPrefix(content.Value, "value-") {
+		t.Fatalf("parameter value wasn't replaced: %s", content.Value)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	anonymize := func(seed string) string {
+		cluster := &fulfillmentv1.Cluster{Id: "123"}
+		err := (&processor{seed: []byte(seed)}).Process(cluster)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return cluster.Id
+	}
+	if anonymize("a") != anonymize("a") {
+		t.Fatalf("same seed produced different pseudonyms")
+	}
+	if anonymize("a") == anonymize("b") {
+		t.Fatalf("different seeds produced the same pseudonym")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claims contains the claims of a JSON web token that are useful to identify the user. Note that the signature of the
+// token isn't verified, that is the job of the server, so this should only be used to display information.
+type Claims struct {
+	Subject  string
+	Issuer   string
+	Audience []string
+	Username string
+	Email    string
+	Scopes   []string
+	IssuedAt time.Time
+	Expiry   time.Time
+}
+
+// ParseClaims extracts the claims from the payload of the given JSON web token.
+func ParseClaims(token string) (result *Claims, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		err = fmt.Errorf("token isn't a JSON web token")
+		return
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		err = fmt.Errorf("failed to decode token payload: %v", err)
+		return
+	}
+	var raw struct {
+		Sub               string          `json:"sub"`
+		Iss               string          `json:"iss"`
+		Aud               json.RawMessage `json:"aud"`
+		PreferredUsername string          `json:"preferred_username"`
+		Email             string          `json:"email"`
+		Scope             string          `json:"scope"`
+		Scp               []string        `json:"scp"`
+		Iat               int64           `json:"iat"`
+		Exp               int64           `json:"exp"`
+	}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		err = fmt.Errorf("failed to parse token payload: %v", err)
+		return
+	}
+	result = &Claims{
+		Subject:  raw.Sub,
+		Issuer:   raw.Iss,
+		Username: raw.PreferredUsername,
+		Email:    raw.Email,
+		Scopes:   raw.Scp,
+	}
+
+	// The audience can be a single string or an array of strings:
+	if len(raw.Aud) > 0 {
+		var audience string
+		if json.Unmarshal(raw.Aud, &audience) == nil {
+			result.Audience = []string{audience}
+		} else {
+			err = json.Unmarshal(raw.Aud, &result.Audience)
+			if err != nil {
+				err = fmt.Errorf("failed to parse token audience: %v", err)
+				return
+			}
+		}
+	}
+
+	// Most providers use the space separated 'scope' claim, but some use the 'scp' array:
+	if raw.Scope != "" {
+		result.Scopes = strings.Fields(raw.Scope)
+	}
+
+	if raw.Iat != 0 {
+		result.IssuedAt = time.Unix(raw.Iat, 0)
+	}
+	if raw.Exp != 0 {
+		result.Expiry = time.Unix(raw.Exp, 0)
+	}
+	return
+}
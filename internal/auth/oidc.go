@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package auth contains the code that obtains and refreshes the tokens used to authenticate to the server.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultScopes are the scopes requested when the user doesn't specify others. The 'offline_access' scope is
+// needed to get a refresh token.
+var DefaultScopes = []string{
+	"openid",
+	"offline_access",
+}
+
+// Provider contains the details of an OpenID Connect provider, as returned by its discovery endpoint.
+type Provider struct {
+	Issuer                      string `json:"issuer"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	RevocationEndpoint          string `json:"revocation_endpoint"`
+}
+
+// Discover retrieves the details of the OpenID Connect provider with the given issuer URL.
+func Discover(ctx context.Context, issuer string) (result *Provider, err error) {
+	address := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		err = fmt.Errorf("failed to create discovery request: %w", err)
+		return
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		err = fmt.Errorf("failed to send discovery request to '%s': %w", address, err)
+		return
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf(
+			"discovery request to '%s' failed with status code %d",
+			address, response.StatusCode,
+		)
+		return
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("failed to read discovery response from '%s': %w", address, err)
+		return
+	}
+	result = &Provider{}
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		err = fmt.Errorf("failed to parse discovery response from '%s': %w", address, err)
+		return
+	}
+	if result.TokenEndpoint == "" {
+		err = fmt.Errorf("provider '%s' doesn't have a token endpoint", issuer)
+		return
+	}
+	return
+}
+
+// DeviceLogin obtains a token using the OAuth2 device authorization grant. It writes to the given writer the URL that
+// the user should open and the code to enter there, and then waits till the user completes the authorization.
+func DeviceLogin(ctx context.Context, provider *Provider, clientID string, scopes []string,
+	writer io.Writer) (result *oauth2.Token, err error) {
+	if provider.DeviceAuthorizationEndpoint == "" {
+		err = fmt.Errorf("provider '%s' doesn't support the device authorization grant", provider.Issuer)
+		return
+	}
+	config := &oauth2.Config{
+		ClientID: clientID,
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: provider.DeviceAuthorizationEndpoint,
+			TokenURL:      provider.TokenEndpoint,
+		},
+		Scopes: scopes,
+	}
+	authorization, err := config.DeviceAuth(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to start device authorization: %w", err)
+		return
+	}
+	if authorization.VerificationURIComplete != "" {
+		fmt.Fprintf(writer, "Open the following URL to log in:\n\n")
+		fmt.Fprintf(writer, "  %s\n\n", authorization.VerificationURIComplete)
+		fmt.Fprintf(writer, "Check that the code displayed there is %s.\n", authorization.UserCode)
+	} else {
+		fmt.Fprintf(writer, "Open the following URL to log in:\n\n")
+		fmt.Fprintf(writer, "  %s\n\n", authorization.VerificationURI)
+		fmt.Fprintf(writer, "And enter the code %s.\n", authorization.UserCode)
+	}
+	fmt.Fprintf(writer, "Waiting for the authorization to complete ...\n")
+	result, err = config.DeviceAccessToken(ctx, authorization)
+	if err != nil {
+		err = fmt.Errorf("failed to complete device authorization: %w", err)
+		return
+	}
+	return
+}
+
+// Revoke asks the provider to revoke the given refresh token, using the revocation endpoint described in RFC 7009.
+func Revoke(ctx context.Context, endpoint, clientID, token string) error {
+	form := url.Values{
+		"token":           {token},
+		"token_type_hint": {"refresh_token"},
+		"client_id":       {clientID},
+	}
+	request, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send revocation request to '%s': %w", endpoint, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"revocation request to '%s' failed with status code %d",
+			endpoint, response.StatusCode,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// SaveFunc is the type of the functions that are called to store the tokens when they are refreshed.
+type SaveFunc func(accessToken, refreshToken string) error
+
+// NewTokenSource creates a token source that returns the given access token while it is valid, and that uses the
+// refresh token to obtain a new one from the token endpoint when it expires. When the tokens are refreshed the save
+// function is called, so that the next execution of the tool can use them instead of refreshing them again.
+//
+// The expiration time of the access token is extracted from its 'exp' claim. Tokens that aren't JSON web tokens, or
+// that don't have that claim, are used as they are, without trying to refresh them.
+func NewTokenSource(clientID, tokenEndpoint, accessToken, refreshToken string, save SaveFunc) oauth2.TokenSource {
+	config := &oauth2.Config{
+		ClientID: clientID,
+		Endpoint: oauth2.Endpoint{
+			TokenURL: tokenEndpoint,
+		},
+	}
+	initial := &oauth2.Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Expiry:       expiry(accessToken),
+	}
+	return &savingTokenSource{
+		source: config.TokenSource(context.Background(), initial),
+		save:   save,
+		last:   accessToken,
+	}
+}
+
+// savingTokenSource is a token source that calls a function to store the tokens when they change.
+type savingTokenSource struct {
+	source oauth2.TokenSource
+	save   SaveFunc
+	lock   sync.Mutex
+	last   string
+}
+
+// Token is the implementation of the oauth2.TokenSource interface.
+func (s *savingTokenSource) Token() (result *oauth2.Token, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	result, err = s.source.Token()
+	if err != nil {
+		err = fmt.Errorf("failed to refresh token, run the 'login' command again: %w", err)
+		return
+	}
+	if result.AccessToken != s.last {
+		s.last = result.AccessToken
+		// Failing to save the tokens shouldn't prevent using them, it only means that they will be refreshed again
+		// next time:
+		err = s.save(result.AccessToken, result.RefreshToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save refreshed token: %v\n", err)
+			err = nil
+		}
+	}
+	return
+}
+
+// expiry returns the expiration time of the given access token, taken from the 'exp' claim if it is a JSON web token.
+// It returns the zero time, meaning that the token doesn't expire, if it isn't possible to extract it.
+func expiry(token string) time.Time {
+	claims, err := ParseClaims(token)
+	if err != nil {
+		return time.Time{}
+	}
+	return claims.Expiry
+}
@@ -0,0 +1,240 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+// expandedArgs contains the result of the last call to ExpandArgs. It is used to record the command line that was
+// actually executed, so that recorded sessions don't depend on the aliases of the configuration file.
+var expandedArgs []string
+
+// ExpandArgs replaces the alias used as first argument, if any, with the command line defined for it in the
+// 'aliases' section of the configuration file, and adds the flags of the 'default_flags' section for the command
+// that will be executed. Aliases never replace the commands of the tool. The default flags are added before the flags
+// given in the command line, and they are ignored when the command line contains a flag with the same name.
+//
+// If the configuration file can't be loaded the arguments are returned unchanged and a warning is written, so that
+// a broken configuration file doesn't prevent the use of the commands that don't need it, or of those that can be
+// used to repair it. The commands that need the configuration will report the error.
+func ExpandArgs(root *cobra.Command, args []string) (result []string, err error) {
+	defer func() {
+		expandedArgs = result
+	}()
+	cfg, err := config.LoadSettings()
+	if err != nil {
+		if !isCompletionArgs(args) {
+			fmt.Fprintf(os.Stderr, "Warning: aliases and default flags aren't used: %v\n", err)
+		}
+		result = args
+		err = nil
+		return
+	}
+	if len(cfg.Aliases) == 0 && len(cfg.DefaultFlags) == 0 {
+		result = args
+		return
+	}
+
+	// Requests generated by the shell to complete the command line contain the words typed by the user after the
+	// name of the hidden completion command. The last word is the one being completed, so it isn't expanded.
+	var prefix []string
+	if isCompletionArgs(args) {
+		prefix = args[:1]
+		args = args[1:]
+		if len(args) < 2 {
+			result = append(prefix, args...)
+			return
+		}
+	}
+
+	// Replace the alias:
+	if len(args) > 0 {
+		value, ok := cfg.Aliases[args[0]]
+		if ok && !isCommand(root, args[0]) {
+			var words []string
+			words, err = splitWords(value)
+			if err != nil {
+				err = fmt.Errorf("failed to parse alias '%s': %w", args[0], err)
+				return
+			}
+			if len(words) == 0 {
+				err = fmt.Errorf("alias '%s' is empty", args[0])
+				return
+			}
+			args = append(words, args[1:]...)
+		}
+	}
+
+	// Add the default flags of the command, except for the completion requests, as they could hide the flags that
+	// the user wants to complete:
+	if prefix == nil {
+		args, err = addDefaultFlags(root, args, cfg.DefaultFlags)
+		if err != nil {
+			return
+		}
+	}
+
+	result = append(prefix, args...)
+	return
+}
+
+// isCompletionArgs checks if the arguments are a request generated by the shell to complete the command line.
+func isCompletionArgs(args []string) bool {
+	return len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd)
+}
+
+// isCommand checks if the given name is the name or one of the aliases of a command of the tool.
+func isCommand(root *cobra.Command, name string) bool {
+	for _, command := range root.Commands() {
+		if command.HasAlias(name) || command.Name() == name {
+			return true
+		}
+	}
+	return name == "help" || name == "completion"
+}
+
+// addDefaultFlags finds the command that corresponds to the given arguments and returns the arguments with the
+// default flags of that command added after the names of the commands.
+func addDefaultFlags(root *cobra.Command, args []string, defaults map[string]string) (result []string, err error) {
+	result = args
+	command, rest, err := root.Find(args)
+	if err != nil || command == root {
+		err = nil
+		return
+	}
+	path := strings.TrimPrefix(command.CommandPath(), root.Name()+" ")
+	value, ok := defaults[path]
+	if !ok {
+		return
+	}
+	words, err := splitWords(value)
+	if err != nil {
+		err = fmt.Errorf("failed to parse default flags of command '%s': %w", path, err)
+		return
+	}
+
+	if len(words) > 0 && flagName(command, words[0]) == "" {
+		err = fmt.Errorf(
+			"default flags of command '%s' should start with a flag, but they start with '%s'",
+			path, words[0],
+		)
+		return
+	}
+
+	// Remove the default flags that are also given in the command line, together with their values:
+	given := map[string]bool{}
+	for _, arg := range rest {
+		if arg == "--" {
+			break
+		}
+		name := flagName(command, arg)
+		if name != "" {
+			given[name] = true
+		}
+	}
+	var added []string
+	skip := false
+	for _, word := range words {
+		name := flagName(command, word)
+		if name != "" {
+			skip = given[name]
+		}
+		if !skip {
+			added = append(added, word)
+		}
+	}
+
+	// The names of the commands are taken from the path, as the arguments may contain their aliases:
+	result = strings.Fields(path)
+	result = append(result, added...)
+	result = append(result, rest...)
+	return
+}
+
+// flagName returns the name of the flag used in the given argument, resolving the shorthands, or an empty string if
+// the argument isn't a flag.
+func flagName(command *cobra.Command, arg string) string {
+	switch {
+	case strings.HasPrefix(arg, "--") && len(arg) > 2:
+		name, _, _ := strings.Cut(arg[2:], "=")
+		return name
+	case strings.HasPrefix(arg, "-") && len(arg) > 1:
+		flag := command.Flags().ShorthandLookup(arg[1:2])
+		if flag == nil {
+			flag = command.InheritedFlags().ShorthandLookup(arg[1:2])
+		}
+		if flag != nil {
+			return flag.Name
+		}
+		return arg[1:2]
+	default:
+		return ""
+	}
+}
+
+// splitWords splits the given text into words separated by spaces. Single and double quotes can be used to include
+// spaces in a word, and backslashes to escape the next character, except inside single quotes.
+func splitWords(text string) (result []string, err error) {
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range text {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				result = append(result, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		err = fmt.Errorf("unterminated quote in '%s'", text)
+		return
+	}
+	if escaped {
+		err = fmt.Errorf("unterminated escape in '%s'", text)
+		return
+	}
+	if inWord {
+		result = append(result, word.String())
+	}
+	return
+}
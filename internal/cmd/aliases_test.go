@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+		err      bool
+	}{
+		{text: "", expected: nil},
+		{text: "   ", expected: nil},
+		{text: "get clusters", expected: []string{"get", "clusters"}},
+		{text: "  get \t clusters\n", expected: []string{"get", "clusters"}},
+		{text: "--columns 'ID,STATE,API URL'", expected: []string{"--columns", "ID,STATE,API URL"}},
+		{text: "--filter \"state == 'READY'\"", expected: []string{"--filter", "state == 'READY'"}},
+		{text: "a''b", expected: []string{"ab"}},
+		{text: "''", expected: []string{""}},
+		{text: "a\\ b", expected: []string{"a b"}},
+		{text: "'a\\b'", expected: []string{"a\\b"}},
+		{text: "\"a\\\"b\"", expected: []string{"a\"b"}},
+		{text: "'unterminated", err: true},
+		{text: "trailing\\", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			actual, err := splitWords(test.text)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected an error, but got %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(actual, test.expected) {
+				t.Fatalf("expected %q, but got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestExpandArgs(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	file := filepath.Join(dir, "fulfillment-cli", "config.json")
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(file, []byte(`{
+		"aliases": {
+			"gc": "get clusters --sort-by 'cluster.metadata.creation_timestamp'",
+			"version": "get clusters"
+		},
+		"default_flags": {
+			"get cluster": "-o wide --no-headers"
+		}
+	}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{
+			args: []string{"gc"},
+			expected: []string{
+				"get", "cluster", "-o", "wide", "--no-headers",
+				"--sort-by", "cluster.metadata.creation_timestamp",
+			},
+		},
+		{
+			args:     []string{"get", "clusters", "-o", "json", "123"},
+			expected: []string{"get", "cluster", "--no-headers", "-o", "json", "123"},
+		},
+		{
+			args:     []string{"get", "clusters", "--output=name"},
+			expected: []string{"get", "cluster", "--no-headers", "--output=name"},
+		},
+		{
+			args:     []string{"get", "clusters", "--", "-o"},
+			expected: []string{"get", "cluster", "-o", "wide", "--no-headers", "--", "-o"},
+		},
+		{
+			args:     []string{"version"},
+			expected: []string{"version"},
+		},
+		{
+			args:     []string{"get", "clusterorders"},
+			expected: []string{"get", "clusterorders"},
+		},
+		{
+			args:     []string{"__complete", "gc", ""},
+			expected: []string{"__complete", "get", "clusters", "--sort-by", "cluster.metadata.creation_timestamp", ""},
+		},
+		{
+			args:     []string{"__complete", "gc"},
+			expected: []string{"__complete", "gc"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			actual, err := ExpandArgs(Root(), test.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(actual, test.expected) {
+				t.Fatalf("expected %q, but got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestExpandArgsWithBrokenConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	file := filepath.Join(dir, "fulfillment-cli", "config.json")
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(file, []byte(`{"version": 1000, "aliases": {"gc": "get clusters"}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"config", "unset", "adress"}
+	actual, err := ExpandArgs(Root(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(actual, args) {
+		t.Fatalf("expected %q, but got %q", args, actual)
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package bench
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paging"
+	"github.com/innabox/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "bench [flags]",
+		Short: "Measure the latency and throughput of the server",
+		Long: "Measure the latency and throughput of the calls that list and get objects of a type. Each call is " +
+			"repeated the given number of times, using the given number of concurrent requests, and then the " +
+			"median (p50), the 95th percentile (p95) and the maximum latencies are reported, together with the " +
+			"number of calls per second. The latencies are measured in the client, so they include the network " +
+			"and the processing done by the CLI itself, like adding the authentication token.",
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.kind,
+		"type",
+		"",
+		"Type of the objects, one of 'clusters', 'clusterorders' or 'clustertemplates'",
+	)
+	flags.IntVar(
+		&runner.iterations,
+		"iterations",
+		50,
+		"Number of times that each call is repeated",
+	)
+	flags.IntVar(
+		&runner.concurrency,
+		"concurrency",
+		8,
+		"Number of calls that are sent to the server at the same time",
+	)
+	flags.Int32Var(
+		&runner.pageSize,
+		"page-size",
+		paging.DefaultSize,
+		"Number of objects requested by each list call",
+	)
+	return result
+}
+
+type runnerContext struct {
+	kind        string
+	iterations  int
+	concurrency int
+	pageSize    int32
+}
+
+// operation is one of the calls that are measured.
+type operation struct {
+	name string
+	call func(ctx context.Context) error
+}
+
+// result contains the measurements of an operation.
+type result struct {
+	name      string
+	latencies []time.Duration
+	errors    int
+	elapsed   time.Duration
+	err       error
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the flags:
+	if c.kind == "" {
+		return fmt.Errorf("flag '--type' is mandatory")
+	}
+	if c.iterations <= 0 {
+		return fmt.Errorf("number of iterations should be positive, but it is %d", c.iterations)
+	}
+	if c.concurrency <= 0 {
+		return fmt.Errorf("concurrency should be positive, but it is %d", c.concurrency)
+	}
+	if c.pageSize <= 0 {
+		return fmt.Errorf("page size should be positive, but it is %d", c.pageSize)
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Prepare the operations. This also sends a first list request, that isn't measured, to establish the connection
+	// and to find an object that can be used for the get calls.
+	operations, err := c.operations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// Run the operations one after the other, so that they don't affect each other:
+	results := make([]*result, len(operations))
+	for i, operation := range operations {
+		results[i] = c.measure(ctx, operation)
+	}
+
+	// Display the results:
+	table := output.NewTable(
+		os.Stdout,
+		output.Column{Header: "OPERATION"},
+		output.Column{Header: "CALLS", Align: output.AlignRight},
+		output.Column{Header: "ERRORS", Align: output.AlignRight},
+		output.Column{Header: "P50", Align: output.AlignRight},
+		output.Column{Header: "P95", Align: output.AlignRight},
+		output.Column{Header: "MAX", Align: output.AlignRight},
+		output.Column{Header: "CALLS/S", Align: output.AlignRight},
+	)
+	rows := make([][]string, len(results))
+	for i, result := range results {
+		rows[i] = []string{
+			result.name,
+			strconv.Itoa(len(result.latencies)),
+			strconv.Itoa(result.errors),
+			formatLatency(percentile(result.latencies, 0.50)),
+			formatLatency(percentile(result.latencies, 0.95)),
+			formatLatency(percentile(result.latencies, 1)),
+			fmt.Sprintf("%.1f", float64(len(result.latencies))/result.elapsed.Seconds()),
+		}
+	}
+	table.Write(rows)
+
+	// Report the first error of each operation, as the rest are usually the same:
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %d %s calls failed, the first one with: %v\n", result.errors, result.name,
+				result.err)
+		}
+	}
+
+	return nil
+}
+
+// operations returns the operations that will be measured for the requested type of object.
+func (c *runnerContext) operations(ctx context.Context, conn *grpc.ClientConn) (result []operation, err error) {
+	limit := c.pageSize
+	var list func(ctx context.Context, limit int32) (ids []string, err error)
+	var get func(ctx context.Context, id string) error
+	switch c.kind {
+	case "cluster", "clusters":
+		client := fulfillmentv1.NewClustersClient(conn)
+		list = func(ctx context.Context, limit int32) (ids []string, err error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClustersListRequest{
+				Limit: &limit,
+			})
+			for _, item := range response.GetItems() {
+				ids = append(ids, item.Id)
+			}
+			return
+		}
+		get = func(ctx context.Context, id string) error {
+			_, err := client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
+				Id: id,
+			})
+			return err
+		}
+	case "clusterorder", "clusterorders":
+		client := fulfillmentv1.NewClusterOrdersClient(conn)
+		list = func(ctx context.Context, limit int32) (ids []string, err error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterOrdersListRequest{
+				Limit: &limit,
+			})
+			for _, item := range response.GetItems() {
+				ids = append(ids, item.Id)
+			}
+			return
+		}
+		get = func(ctx context.Context, id string) error {
+			_, err := client.Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+				Id: id,
+			})
+			return err
+		}
+	case "clustertemplate", "clustertemplates":
+		client := fulfillmentv1.NewClusterTemplatesClient(conn)
+		list = func(ctx context.Context, limit int32) (ids []string, err error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+				Limit: &limit,
+			})
+			for _, item := range response.GetItems() {
+				ids = append(ids, item.Id)
+			}
+			return
+		}
+		get = func(ctx context.Context, id string) error {
+			_, err := client.Get(ctx, &fulfillmentv1.ClusterTemplatesGetRequest{
+				Id: id,
+			})
+			return err
+		}
+	default:
+		err = fmt.Errorf(
+			"unsupported object type '%s', valid types are 'clusters', 'clusterorders' and 'clustertemplates'",
+			c.kind,
+		)
+		return
+	}
+	ids, err := list(ctx, 1)
+	if err != nil {
+		err = fmt.Errorf("failed to list objects: %w", err)
+		return
+	}
+	result = append(result, operation{
+		name: "list",
+		call: func(ctx context.Context) error {
+			_, err := list(ctx, limit)
+			return err
+		},
+	})
+	if len(ids) == 0 {
+		terminal.Infof(os.Stderr, "There are no %s, the get calls will not be measured\n", c.kind)
+		return
+	}
+	id := ids[0]
+	result = append(result, operation{
+		name: "get",
+		call: func(ctx context.Context) error {
+			return get(ctx, id)
+		},
+	})
+	return
+}
+
+// measure calls the operation the requested number of times, with the requested concurrency, and returns the
+// latencies of the calls that succeeded and the number of calls that failed.
+func (c *runnerContext) measure(ctx context.Context, operation operation) *result {
+	spinner := terminal.StartSpinner(os.Stderr, fmt.Sprintf("Measuring %s calls", operation.name))
+	defer spinner.Stop()
+	result := &result{
+		name: operation.name,
+	}
+	var lock sync.Mutex
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range c.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				before := time.Now()
+				err := operation.call(ctx)
+				latency := time.Since(before)
+				lock.Lock()
+				if err != nil {
+					result.errors++
+					if result.err == nil {
+						result.err = err
+					}
+				} else {
+					result.latencies = append(result.latencies, latency)
+				}
+				spinner.Updatef(
+					"Measuring %s calls, %d of %d done",
+					operation.name, len(result.latencies)+result.errors, c.iterations,
+				)
+				lock.Unlock()
+			}
+		}()
+	}
+	for i := range c.iterations {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	result.elapsed = time.Since(start)
+	return result
+}
+
+// percentile returns the given percentile of the latencies, using the nearest rank method. It returns a negative
+// value if there are no latencies.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return -1
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	rank = max(rank, 0)
+	return sorted[rank]
+}
+
+// formatLatency converts the latency to text, rounded to a tenth of millisecond. Negative latencies, used when there
+// are no measurements, are converted to a dash.
+func formatLatency(latency time.Duration) string {
+	if latency < 0 {
+		return "-"
+	}
+	return latency.Round(100 * time.Microsecond).String()
+}
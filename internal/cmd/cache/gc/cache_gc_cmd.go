@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package gc
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paths"
+	"github.com/innabox/fulfillment-cli/internal/recording"
+	"github.com/innabox/fulfillment-cli/internal/snapshots"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "gc [flags]",
+		Short: "Remove old local files",
+		Long: "Remove the local files that are older than the retention period: snapshots of objects, the session " +
+			"being recorded if it was abandoned, and the contents of the cache directory. The files are selected " +
+			"by modification time, and the space reclaimed is reported.",
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.DurationVar(
+		&runner.snapshots,
+		"snapshots",
+		30*24*time.Hour,
+		"Retention of the snapshots of objects. Changes older than this will not be reported by the 'changes' "+
+			"command.",
+	)
+	flags.DurationVar(
+		&runner.sessions,
+		"sessions",
+		7*24*time.Hour,
+		"Retention of the session being recorded. A session that wasn't updated during this time is considered "+
+			"abandoned.",
+	)
+	flags.DurationVar(
+		&runner.cache,
+		"cache",
+		7*24*time.Hour,
+		"Retention of the files in the cache directory",
+	)
+	flags.BoolVar(
+		&runner.dryRun,
+		"dry-run",
+		false,
+		"Report what would be removed, without removing anything",
+	)
+	return result
+}
+
+type runnerContext struct {
+	snapshots time.Duration
+	sessions  time.Duration
+	cache     time.Duration
+	dryRun    bool
+}
+
+// area is a set of local files that share the same retention.
+type area struct {
+	name      string
+	location  func() (string, error)
+	retention time.Duration
+	files     int
+	size      int64
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Prune the areas:
+	now := time.Now()
+	areas := []*area{
+		{
+			name:      "snapshots",
+			location:  snapshots.Location,
+			retention: c.snapshots,
+		},
+		{
+			name:      "sessions",
+			location:  recording.Location,
+			retention: c.sessions,
+		},
+		{
+			name:      "cache",
+			location:  paths.CacheDir,
+			retention: c.cache,
+		},
+	}
+	for _, area := range areas {
+		location, err := area.location()
+		if err != nil {
+			return err
+		}
+		area.files, area.size, err = c.prune(location, now.Add(-area.retention))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Report the results:
+	table := output.NewTable(
+		os.Stdout,
+		output.Column{Header: "AREA"},
+		output.Column{Header: "RETENTION"},
+		output.Column{Header: "FILES", Align: output.AlignRight},
+		output.Column{Header: "SIZE", Align: output.AlignRight},
+	)
+	rows := make([][]string, len(areas))
+	var total int64
+	for i, area := range areas {
+		rows[i] = []string{
+			area.name,
+			area.retention.String(),
+			strconv.Itoa(area.files),
+			formatSize(area.size),
+		}
+		total += area.size
+	}
+	table.Write(rows)
+	if c.dryRun {
+		fmt.Printf("Would reclaim %s (dry run)\n", formatSize(total))
+	} else {
+		fmt.Printf("Reclaimed %s\n", formatSize(total))
+	}
+
+	return nil
+}
+
+// prune removes the files inside the given location, that can be a file or a directory, that were modified before the
+// given time. Directories that become empty are removed too, except the location itself. It returns the number of
+// files removed and their total size.
+func (c *runnerContext) prune(location string, before time.Time) (files int, size int64, err error) {
+	var dirs []string
+	err = filepath.WalkDir(location, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			if path != location {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !info.ModTime().Before(before) {
+			return nil
+		}
+		if !c.dryRun {
+			err = os.Remove(path)
+			if err != nil {
+				return err
+			}
+		}
+		files++
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to prune '%s': %w", location, err)
+		return
+	}
+	if c.dryRun {
+		return
+	}
+
+	// Remove the empty directories, starting with the deepest ones so that parents that only contained empty
+	// directories are also removed:
+	slices.Reverse(dirs)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			continue
+		}
+		os.Remove(dir)
+	}
+	return
+}
+
+// formatSize converts a number of bytes to text using binary units, for example '1.5 MiB'.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TiB", value)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/experimental"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/snapshots"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "changes [flags] OBJECT ID",
+		Short: "Show what changed in an object",
+		Long: "Show the fields of an object that changed recently, and when. The server doesn't keep the history " +
+			"of the objects, so this is calculated comparing the snapshots that are saved locally every time that " +
+			"the object is retrieved with the 'describe' or 'changes' commands, or with the 'get' command if the " +
+			"'save_snapshots' setting is enabled, for example with 'config set save_snapshots true'. The " +
+			"supported objects are 'cluster', 'clusterorder' and 'clustertemplate'.",
+		RunE:              runner.run,
+		ValidArgsFunction: runner.complete,
+	}
+	flags := result.Flags()
+	flags.DurationVar(
+		&runner.since,
+		"since",
+		24*time.Hour,
+		"Only show changes newer than this",
+	)
+	experimental.MarkCommand(result)
+	return result
+}
+
+type runnerContext struct {
+	since time.Duration
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check that there is exactly one object type and one identifier:
+	if len(args) != 2 {
+		return fmt.Errorf("expected exactly one object type and one identifier")
+	}
+	kind := args[0]
+	id := args[1]
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Get the current version of the object and save it, so that it will be the last snapshot:
+	kind, object, err := c.get(ctx, conn, kind, id)
+	if err != nil {
+		return err
+	}
+	err = snapshots.Save(kind, id, object)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	// Load the snapshots and compare each one with the previous one. Note that the last snapshot before the start of
+	// the period is used as the base for the first comparison.
+	list, err := snapshots.Load(kind, id)
+	if err != nil {
+		return fmt.Errorf("failed to load snapshots: %w", err)
+	}
+	start := time.Now().Add(-c.since)
+	first := 0
+	for first+1 < len(list) && !list[first+1].Time.After(start) {
+		first++
+	}
+	if first == len(list)-1 {
+		fmt.Printf(
+			"There are no previous snapshots of %s '%s', changes will be reported from now on\n",
+			kind, id,
+		)
+		return nil
+	}
+	var changes []snapshots.Change
+	for i := first + 1; i < len(list); i++ {
+		changes = append(changes, snapshots.Diff(list[i-1], list[i])...)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("No changes detected in %s '%s' since %s\n", kind, id, output.Time(list[first].Time))
+		return nil
+	}
+
+	// Display the changes:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TIME\tFIELD\tOLD\tNEW\n")
+	for _, change := range changes {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\n",
+			output.Time(change.Time),
+			change.Field,
+			renderValue(change.Old),
+			renderValue(change.New),
+		)
+	}
+	writer.Flush()
+
+	return nil
+}
+
+// complete suggests the object types for the first argument, and the identifiers of the objects of that type for the
+// second.
+func (c *runnerContext) complete(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return []string{"cluster", "clusterorder", "clustertemplate"}, cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		switch args[0] {
+		case "cluster", "clusters":
+			return completion.Clusters(cmd, args, toComplete)
+		case "clusterorder", "clusterorders":
+			return completion.ClusterOrders(cmd, args, toComplete)
+		case "clustertemplate", "clustertemplates":
+			return completion.ClusterTemplates(cmd, args, toComplete)
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// get retrieves the object with the given type and identifier. It returns the canonical name of the type, so that
+// aliases like 'clusters' will use the same snapshots than 'cluster'.
+func (c *runnerContext) get(ctx context.Context, conn *grpc.ClientConn, kind, id string) (canonical string,
+	result proto.Message, err error) {
+	switch kind {
+	case "cluster", "clusters":
+		canonical = "cluster"
+		client := fulfillmentv1.NewClustersClient(conn)
+		var response *fulfillmentv1.ClustersGetResponse
+		response, err = client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
+			Id: id,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to get cluster: %w", err)
+			return
+		}
+		result = response.Object
+	case "clusterorder", "clusterorders":
+		canonical = "clusterorder"
+		client := fulfillmentv1.NewClusterOrdersClient(conn)
+		var response *fulfillmentv1.ClusterOrdersGetResponse
+		response, err = client.Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+			Id: id,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to get order: %w", err)
+			return
+		}
+		result = response.Object
+	case "clustertemplate", "clustertemplates":
+		canonical = "clustertemplate"
+		client := fulfillmentv1.NewClusterTemplatesClient(conn)
+		var response *fulfillmentv1.ClusterTemplatesGetResponse
+		response, err = client.Get(ctx, &fulfillmentv1.ClusterTemplatesGetRequest{
+			Id: id,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to get template: %w", err)
+			return
+		}
+		result = response.Object
+	default:
+		err = fmt.Errorf(
+			"unsupported object type '%s', valid types are 'cluster', 'clusterorder' and 'clustertemplate'",
+			kind,
+		)
+	}
+	return
+}
+
+func renderValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		return typed
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprintf("%v", typed)
+		}
+		return string(data)
+	}
+}
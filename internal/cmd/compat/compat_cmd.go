@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package compat
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/compat"
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "compat [flags]",
+		Short: "Check the compatibility of the tool with the server",
+		Long: "Compare the API that the tool was built with to the API that the server advertises with the gRPC " +
+			"reflection service, and report the services, methods, fields and enum values that only one of them " +
+			"knows about. Elements that only the server knows about usually mean that a newer version of the tool " +
+			"is available.",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Compare the descriptors:
+	differences, err := compat.Check(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("failed to check compatibility: %w", err)
+	}
+	if len(differences) == 0 {
+		fmt.Printf("The tool and the server use the same API\n")
+		return nil
+	}
+
+	// Display the differences:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "KIND\tNAME\tONLY IN\n")
+	onlyServer := 0
+	for _, difference := range differences {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", difference.Kind, difference.Name, difference.Side)
+		if difference.Side == compat.OnlyServer {
+			onlyServer++
+		}
+	}
+	writer.Flush()
+	if onlyServer > 0 {
+		fmt.Printf(
+			"\nThe server supports %d elements of the API that this tool doesn't know about, consider updating "+
+				"the tool\n",
+			onlyServer,
+		)
+	}
+	if onlyServer < len(differences) {
+		fmt.Printf(
+			"\nThe tool uses %d elements of the API that the server doesn't support, some commands may fail\n",
+			len(differences)-onlyServer,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/cmd/config/set"
+	"github.com/innabox/fulfillment-cli/internal/cmd/config/unset"
+	"github.com/innabox/fulfillment-cli/internal/cmd/config/view"
+)
+
+func Cmd() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "config",
+		Short: "View and change the configuration",
+		Long: "View and change the settings of the configuration file, like the address of the server or the " +
+			"TLS options, without running the 'login' command again. Settings inside sections are named using " +
+			"dots, for example 'oidc.issuer'.\n\n" +
+			"The 'aliases' and 'default_flags' sections need to be changed editing the configuration file. The " +
+			"first defines shortcuts for other commands, for example '\"gc\": \"get clusters -o wide\"' makes " +
+			"'fulfillment-cli gc' equivalent to 'fulfillment-cli get clusters -o wide'. The second defines flags " +
+			"that are added to commands unless the same flags are given in the command line, for example " +
+			"'\"get cluster\": \"--sort-by cluster.metadata.creation_timestamp\"'. Aliases are only expanded " +
+			"when they are the first argument, and they can't replace the commands of the tool.",
+	}
+	result.AddCommand(set.Cmd())
+	result.AddCommand(unset.Cmd())
+	result.AddCommand(view.Cmd())
+	return result
+}
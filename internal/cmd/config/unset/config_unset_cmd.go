@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package unset
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "unset [flags] KEY",
+		Short: "Restore the default value of a setting of the configuration",
+		Long: "Restore the default value of a setting of the configuration, for example 'config unset insecure'. " +
+			"Sections, like 'oidc', that are left without any setting are removed. Unknown settings of the " +
+			"configuration file, usually typos like 'adress', can also be removed with this command.",
+		RunE:              runner.run,
+		ValidArgsFunction: runner.complete,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the arguments:
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one setting name")
+	}
+
+	// Get the configuration, even if it contains unknown settings, as this command is used to repair it:
+	cfg, err := config.LoadUnchecked()
+	if err != nil {
+		return err
+	}
+
+	// Restore the default value and save the result:
+	err = cfg.Unset(args[0])
+	if err != nil {
+		return err
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		return err
+	}
+	for _, problem := range cfg.Unknown() {
+		fmt.Fprintf(os.Stderr, "Warning: removed %s\n", problem)
+	}
+	fmt.Printf("Setting '%s' unset\n", args[0])
+
+	return nil
+}
+
+// complete suggests the names of the settings for the first argument.
+func (c *runnerContext) complete(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return config.Settings(), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package unset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+func TestUnsetTypoInInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	file := filepath.Join(dir, "fulfillment-cli", "config.json")
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(file, []byte(`{"address": "api.example.com:443", "adress": "typo"}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check that the file is rejected by the commands that use it:
+	_, err = config.Load()
+	if err == nil {
+		t.Fatalf("expected an error for the unknown setting")
+	}
+
+	// Remove the typo:
+	cmd := Cmd()
+	cmd.SetArgs([]string{"adress"})
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Check that the file is now valid, and that the rest of the settings have been preserved:
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Address != "api.example.com:443" {
+		t.Fatalf("expected address 'api.example.com:443', but got '%s'", cfg.Address)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := raw["adress"]; ok {
+		t.Fatalf("unknown setting wasn't removed: %s", data)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "view",
+		Short: "Display the configuration",
+		Long: "Display the configuration in JSON format. The tokens are replaced with the 'REDACTED' text, so the " +
+			"result can be shared safely.",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the configuration, even if it contains unknown settings, as this command is used to repair it:
+	cfg, err := config.LoadUnchecked()
+	if err != nil {
+		return err
+	}
+	for _, problem := range cfg.Unknown() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", problem)
+	}
+
+	// Display it:
+	data, err := json.MarshalIndent(cfg.Redacted(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package count
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/paging"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "count [flags] OBJECT",
+		Short: "Count objects",
+		Long: "Display the number of objects of a type, optionally only the ones that match a filter. The objects " +
+			"themselves aren't retrieved: a list request with a zero limit is sent and the total reported by the " +
+			"server is used. If the server doesn't report the total then the objects are counted retrieving them " +
+			"one page at a time. The supported objects are 'clusters', 'clusterorders' and 'clustertemplates'.",
+		Example: "" +
+			"  # Count the clusters:\n" +
+			"  fulfillment-cli count clusters\n" +
+			"\n" +
+			"  # Count the orders that failed:\n" +
+			"  fulfillment-cli count clusterorders --filter \"state = 'FAILED'\"",
+		RunE:              runner.run,
+		ValidArgsFunction: runner.complete,
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.filter,
+		"filter",
+		"",
+		"Only count the objects that match the given filter. The filter is evaluated by the server.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	filter string
+}
+
+// listFunc is the type of the functions that send a list request and return the number of items received and the
+// total reported by the server, if any.
+type listFunc func(ctx context.Context, offset, limit int32) (items int, total *int32, err error)
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check that there is exactly one object type:
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one object type")
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Prepare the function that lists the objects:
+	list, err := c.list(conn, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Try first to get the total without retrieving any object:
+	items, total, err := list(ctx, 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to count objects: %w", err)
+	}
+	if total != nil && items == 0 {
+		fmt.Printf("%d\n", *total)
+		return nil
+	}
+
+	// The server didn't report the total, or ignored the limit, so count the objects retrieving all the pages:
+	count := 0
+	pager := paging.NewPager(func(ctx context.Context, offset, limit int32) ([]struct{}, int32, error) {
+		items, total, err := list(ctx, offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		var value int32
+		if total != nil {
+			value = *total
+		}
+		return make([]struct{}, items), value, nil
+	}, 0)
+	for {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count objects: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		count += len(page)
+	}
+	fmt.Printf("%d\n", count)
+
+	return nil
+}
+
+// list returns the function that lists the objects of the given type.
+func (c *runnerContext) list(conn *grpc.ClientConn, kind string) (result listFunc, err error) {
+	var filter *string
+	if c.filter != "" {
+		filter = &c.filter
+	}
+	switch kind {
+	case "cluster", "clusters":
+		client := fulfillmentv1.NewClustersClient(conn)
+		result = func(ctx context.Context, offset, limit int32) (items int, total *int32, err error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClustersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+				Filter: filter,
+			})
+			if err != nil {
+				return
+			}
+			items = len(response.Items)
+			total = response.Total
+			return
+		}
+	case "clusterorder", "clusterorders":
+		client := fulfillmentv1.NewClusterOrdersClient(conn)
+		result = func(ctx context.Context, offset, limit int32) (items int, total *int32, err error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterOrdersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+				Filter: filter,
+			})
+			if err != nil {
+				return
+			}
+			items = len(response.Items)
+			total = response.Total
+			return
+		}
+	case "clustertemplate", "clustertemplates":
+		client := fulfillmentv1.NewClusterTemplatesClient(conn)
+		result = func(ctx context.Context, offset, limit int32) (items int, total *int32, err error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+				Filter: filter,
+			})
+			if err != nil {
+				return
+			}
+			items = len(response.Items)
+			total = response.Total
+			return
+		}
+	default:
+		err = fmt.Errorf(
+			"unsupported object type '%s', valid types are 'clusters', 'clusterorders' and 'clustertemplates'",
+			kind,
+		)
+	}
+	return
+}
+
+// complete suggests the object types for the first argument.
+func (c *runnerContext) complete(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return []string{"clusters", "clusterorders", "clustertemplates"}, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -14,14 +14,26 @@ language governing permissions and limitations under the License.
 package clusterorder
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
-	"text/tabwriter"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+	"sigs.k8s.io/yaml"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
 	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/hooks"
+	"github.com/innabox/fulfillment-cli/internal/mutation"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/parameters"
+	"github.com/innabox/fulfillment-cli/internal/reflection"
 )
 
 func Cmd() *cobra.Command {
@@ -29,7 +41,20 @@ func Cmd() *cobra.Command {
 	result := &cobra.Command{
 		Use:   "clusterorder [flags]",
 		Short: "Create a cluster order",
-		RunE:  runner.run,
+		Example: "" +
+			"  # Create a cluster order from a template:\n" +
+			"  fulfillment-cli create clusterorder --template-id ocp-small\n" +
+			"\n" +
+			"  # Create an order setting template parameters:\n" +
+			"  fulfillment-cli create clusterorder --template-id ocp-small --param node_count=3 \\\n" +
+			"    --param-file pull_secret=pull-secret.json\n" +
+			"\n" +
+			"  # Check what would be created, without creating it:\n" +
+			"  fulfillment-cli create clusterorder --template-id ocp-small --dry-run\n" +
+			"\n" +
+			"  # Create an order from a file written by 'export', changing the template:\n" +
+			"  fulfillment-cli create clusterorder --from-file backup/clusterorders/123.json --template-id ocp-large",
+		RunE: runner.run,
 	}
 	flags := result.Flags()
 	flags.StringVar(
@@ -38,14 +63,81 @@ func Cmd() *cobra.Command {
 		"",
 		"Template identifier",
 	)
+	result.RegisterFlagCompletionFunc("template-id", completion.ClusterTemplates)
+	flags.StringVarP(
+		&runner.fromFile,
+		"from-file",
+		"f",
+		"",
+		"Read the order from a JSON or YAML file, or from the standard input if the name is '-'. The file "+
+			"contains one order, like the files written by 'export' to a directory. The 'status' and 'metadata' "+
+			"fields are ignored, and the rest of the flags change the order read from the file.",
+	)
+	flags.StringArrayVar(
+		&runner.sets,
+		"set",
+		nil,
+		"Set a field of the order, with the format 'path=value', for example 'spec.template_id=ocp-small'. "+
+			"Can be used multiple times.",
+	)
+	flags.StringArrayVar(
+		&runner.params,
+		"param",
+		nil,
+		"Set the value of a template parameter, with the format 'name=value', for example 'node_count=3'. The "+
+			"value is converted to the type of the parameter declared in the template. Can be used multiple times.",
+	)
+	flags.StringArrayVar(
+		&runner.paramFiles,
+		"param-file",
+		nil,
+		"Set the value of a template parameter to the content of a file, with the format 'name=path', for "+
+			"example 'pull_secret=pull-secret.json'. The content is converted like the values of the '--param' "+
+			"flag. Can be used multiple times.",
+	)
+	flags.StringArrayVar(
+		&runner.paramJSONs,
+		"param-json",
+		nil,
+		"Set the value of a template parameter from its JSON representation, with the format 'name=json', for "+
+			"example 'config={\"fips\":true}'. Can be used multiple times.",
+	)
+	flags.StringVarP(
+		&runner.output,
+		"output",
+		"o",
+		"",
+		"Output format, one of 'json', 'yaml' or 'name'. The 'json' and 'yaml' formats write the complete order "+
+			"returned by the server, and 'name' writes only the type and identifier of the created order, like "+
+			"'clusterorder/123'. By default only the identifier is written.",
+	)
+	runner.mutation = mutation.AddFlags(flags)
 	return result
 }
 
 type runnerContext struct {
 	templateId string
+	fromFile   string
+	sets       []string
+	params     []string
+	paramFiles []string
+	paramJSONs []string
+	output     string
+	mutation   *mutation.Flags
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the flags:
+	err := c.mutation.Validate()
+	if err != nil {
+		return err
+	}
+	switch c.output {
+	case "", "json", "yaml", "name":
+	default:
+		return fmt.Errorf("output format '%s' isn't supported, it should be 'json', 'yaml' or 'name'", c.output)
+	}
+
 	// Get the context:
 	ctx := cmd.Context()
 
@@ -58,9 +150,34 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("there is no configuration, run the 'login' command")
 	}
 
+	// Prepare the order, starting with the one read from the file, if any:
+	order := &fulfillmentv1.ClusterOrder{}
+	if c.fromFile != "" {
+		err = c.readFile(order)
+		if err != nil {
+			return err
+		}
+	}
+	if order.Spec == nil {
+		order.Spec = &fulfillmentv1.ClusterOrderSpec{}
+	}
+	if c.templateId != "" {
+		order.Spec.TemplateId = c.templateId
+	}
+	for _, set := range c.sets {
+		path, value, found := strings.Cut(set, "=")
+		if !found {
+			return fmt.Errorf("value '%s' of flag '--set' isn't valid, it should have the format 'path=value'", set)
+		}
+		err = reflection.Set(order, path, value)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Check that we have a template:
-	if c.templateId == "" {
-		return fmt.Errorf("template-id is required")
+	if order.GetSpec().GetTemplateId() == "" {
+		return fmt.Errorf("template-id is required, use the '--template-id' flag or the '--from-file' flag")
 	}
 
 	// Create the gRPC connection from the configuration:
@@ -69,29 +186,151 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create gRPC connection: %w", err)
 	}
 
+	// Add the template parameters:
+	if len(c.params) > 0 || len(c.paramFiles) > 0 || len(c.paramJSONs) > 0 {
+		err = c.addParams(ctx, conn, order)
+		if err != nil {
+			return err
+		}
+	}
+
+	// In client dry runs display the order that would be sent to the server, without sending it:
+	request := &fulfillmentv1.ClusterOrdersCreateRequest{
+		Object: order,
+	}
+	if c.mutation.Client() {
+		fmt.Printf("The following cluster order would be created%s:\n", c.mutation.Suffix())
+		return output.JSON(os.Stdout, order)
+	}
+
 	// Create the client for the cluster orders service:
 	client := fulfillmentv1.NewClusterOrdersClient(conn)
 
-	// Prepare the order:
-	order := &fulfillmentv1.ClusterOrder{
-		Spec: &fulfillmentv1.ClusterOrderSpec{
-			TemplateId: c.templateId,
-		},
+	// Run the hook that the user may have configured to check the order before creating it:
+	err = hooks.Pre(ctx, cfg, &hooks.Event{
+		Operation: hooks.OperationCreate,
+		Type:      "clusterorder",
+		Object:    order,
+	})
+	if err != nil {
+		return err
 	}
 
 	// Create the order:
-	response, err := client.Create(ctx, &fulfillmentv1.ClusterOrdersCreateRequest{
-		Object: order,
-	})
+	response, err := client.Create(ctx, request)
 	if err != nil {
 		return fmt.Errorf("failed to create order: %w", err)
 	}
+	order = response.Object
+	hooks.Post(ctx, cfg, &hooks.Event{
+		Operation: hooks.OperationCreate,
+		Type:      "clusterorder",
+		ID:        order.Id,
+		Object:    order,
+	})
 
 	// Display the result:
-	order = response.Object
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID: %s\n", order.Id)
-	writer.Flush()
+	switch c.output {
+	case "json":
+		return output.JSON(os.Stdout, order)
+	case "yaml":
+		return output.YAML(os.Stdout, order)
+	case "name":
+		return output.Name(os.Stdout, "clusterorder", order.Id)
+	default:
+		fmt.Printf("ID: %s\n", order.Id)
+		return nil
+	}
+}
 
+// readFile reads the order from the JSON or YAML file given with the '--from-file' flag, discarding the fields that
+// are assigned by the server.
+func (c *runnerContext) readFile(order *fulfillmentv1.ClusterOrder) error {
+	var data []byte
+	var err error
+	if c.fromFile == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(c.fromFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read order from '%s': %w", c.fromFile, err)
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse order from '%s': %w", c.fromFile, err)
+	}
+	err = protojson.Unmarshal(data, order)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to parse order from '%s', it should contain exactly one order: %w",
+			c.fromFile, err,
+		)
+	}
+	reflection.Clear(order, "status", "metadata")
 	return nil
 }
+
+// addParams fetches the template of the order, and uses the definitions of the parameters to convert the values given
+// with the '--param', '--param-file' and '--param-json' flags and add them to the order.
+func (c *runnerContext) addParams(ctx context.Context, conn *grpc.ClientConn, order *fulfillmentv1.ClusterOrder) error {
+	client := fulfillmentv1.NewClusterTemplatesClient(conn)
+	response, err := client.Get(ctx, &fulfillmentv1.ClusterTemplatesGetRequest{
+		Id: order.Spec.TemplateId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get template '%s': %w", order.Spec.TemplateId, err)
+	}
+	template := response.Object
+	if order.Spec.TemplateParameters == nil {
+		order.Spec.TemplateParameters = map[string]*anypb.Any{}
+	}
+	add := func(flag, format, item string, parse func(*fulfillmentv1.ClusterTemplateParameterDefinition,
+		string) (*anypb.Any, error)) error {
+		name, text, found := strings.Cut(item, "=")
+		if !found {
+			return fmt.Errorf(
+				"value '%s' of flag '%s' isn't valid, it should have the format '%s'",
+				item, flag, format,
+			)
+		}
+		definition, err := parameters.Find(template, name)
+		if err != nil {
+			return err
+		}
+		value, err := parse(definition, text)
+		if err != nil {
+			return err
+		}
+		order.Spec.TemplateParameters[name] = value
+		return nil
+	}
+	for _, param := range c.params {
+		err = add("--param", "name=value", param, parameters.Parse)
+		if err != nil {
+			return err
+		}
+	}
+	for _, param := range c.paramFiles {
+		err = add("--param-file", "name=path", param, readParam)
+		if err != nil {
+			return err
+		}
+	}
+	for _, param := range c.paramJSONs {
+		err = add("--param-json", "name=json", param, parameters.ParseJSON)
+		if err != nil {
+			return err
+		}
+	}
+	return parameters.CheckRequired(template, order.Spec.TemplateParameters)
+}
+
+// readParam reads the value of a template parameter from the file with the given path.
+func readParam(definition *fulfillmentv1.ClusterTemplateParameterDefinition, path string) (*anypb.Any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value of parameter '%s': %w", definition.Name, err)
+	}
+	return parameters.Parse(definition, string(data))
+}
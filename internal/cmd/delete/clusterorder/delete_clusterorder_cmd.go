@@ -14,39 +14,115 @@ language governing permissions and limitations under the License.
 package clusterorder
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
 	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/expressions"
+	"github.com/innabox/fulfillment-cli/internal/hooks"
+	"github.com/innabox/fulfillment-cli/internal/mutation"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paging"
 )
 
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "clusterorder [flags] ID",
+		Use:     "clusterorder [flags] [ID|-]",
 		Aliases: []string{"clusterorders"},
 		Short:   "Delete a cluster order",
-		RunE:    runner.run,
+		Example: "" +
+			"  # Delete a cluster order:\n" +
+			"  fulfillment-cli delete clusterorder 123\n" +
+			"\n" +
+			"  # Delete all the failed orders, without asking for confirmation:\n" +
+			"  fulfillment-cli delete clusterorder --filter \"state = 'FAILED'\" --yes\n" +
+			"\n" +
+			"  # Delete the orders of a template created more than a month ago:\n" +
+			"  fulfillment-cli delete clusterorder --select 'order.spec.template_id == \"small\" && \\\n" +
+			"    since(order.metadata.creation_timestamp) > duration(\"720h\")'\n" +
+			"\n" +
+			"  # Delete the orders whose names are read from the standard input:\n" +
+			"  fulfillment-cli get clusterorders -o name | fulfillment-cli delete clusterorder - --yes",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.First(completion.ClusterOrders),
 	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.filter,
+		"filter",
+		"",
+		"Delete all the orders that match the given filter, for example \"state = 'FULFILLED'\". The filter is "+
+			"evaluated by the server.",
+	)
+	flags.StringVar(
+		&runner.selector,
+		"select",
+		"",
+		"Delete all the orders for which the given CEL expression, that uses the 'order' variable, is true. For "+
+			"example 'order.spec.template_id == \"small\"'. The expression is evaluated by the tool, after "+
+			"retrieving the orders with the same connection, and it can be combined with the '--filter' flag or "+
+			"with the '-' argument.",
+	)
+	flags.StringVarP(
+		&runner.output,
+		"output",
+		"o",
+		"",
+		"Output format. The only supported value is 'name', that writes only the type and identifier of each "+
+			"deleted order, like 'clusterorder/123'.",
+	)
+	runner.mutation = mutation.AddFlags(flags)
 	return result
 }
 
 type runnerContext struct {
+	filter   string
+	selector string
+	output   string
+	mutation *mutation.Flags
+	compiled *expressions.Expression
+	cfg      *config.Config
+	client   fulfillmentv1.ClusterOrdersClient
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
-        // Check that there is exactly one cluster order ID specified
-        if len(args) != 1 {
-	        fmt.Fprintf(
-                        os.Stderr,
-                        "Expected exactly one cluster order ID\n",
-	        )
-                os.Exit(1)
-        }
-	orderId := args[0]
+	// Check that there is exactly one cluster order ID specified, '-' to read them from the standard input, or a
+	// filter or selector:
+	stdin := len(args) == 1 && args[0] == "-"
+	if c.filter == "" && c.selector == "" && len(args) != 1 {
+		return fmt.Errorf(
+			"expected exactly one cluster order ID, '-' to read them from the standard input, or the " +
+				"'--filter' or '--select' flags",
+		)
+	}
+	if len(args) > 1 || (len(args) == 1 && !stdin && (c.filter != "" || c.selector != "")) {
+		return fmt.Errorf("cluster order IDs and the '--filter' or '--select' flags can't be used together")
+	}
+	if stdin && c.filter != "" {
+		return fmt.Errorf("reading cluster order IDs from the standard input and the '--filter' flag can't be " +
+			"used together")
+	}
+	err := c.mutation.Validate()
+	if err != nil {
+		return err
+	}
+	if c.output != "" && c.output != "name" {
+		return fmt.Errorf("output format '%s' isn't supported, it should be 'name'", c.output)
+	}
+	if c.selector != "" {
+		c.compiled, err = expressions.Compile("order", &fulfillmentv1.ClusterOrder{}, c.selector)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Get the context:
 	ctx := cmd.Context()
@@ -67,10 +143,37 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create the client for the cluster orders service:
-	client := fulfillmentv1.NewClusterOrdersClient(conn)
+	c.cfg = cfg
+	c.client = fulfillmentv1.NewClusterOrdersClient(conn)
 
+	if len(args) == 1 && !stdin {
+		return c.deleteOne(ctx, args[0])
+	}
+
+	// Find the orders, either reading their identifiers from the standard input or using the filter, and then
+	// keep only the ones that match the selector:
+	var orders []*fulfillmentv1.ClusterOrder
+	if stdin {
+		orders, err = c.readOrders(ctx)
+	} else {
+		orders, err = c.listOrders(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	if c.compiled != nil {
+		orders, err = expressions.Select(c.compiled, orders)
+		if err != nil {
+			return fmt.Errorf("failed to select orders: %w", err)
+		}
+	}
+	return c.deleteMany(ctx, orders)
+}
+
+// deleteOne deletes the order with the given identifier.
+func (c *runnerContext) deleteOne(ctx context.Context, orderId string) error {
 	// Get the order:
-	_, err = client.Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+	response, err := c.client.Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
 		Id: orderId,
 	})
 	if err != nil {
@@ -78,13 +181,156 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Delete the order:
-	_, err = client.Delete(ctx, &fulfillmentv1.ClusterOrdersDeleteRequest{
-		Id: orderId,
-	})
+	err = c.delete(ctx, response.Object)
 	if err != nil {
 		return fmt.Errorf("failed to delete order: %w", err)
 	}
-	fmt.Printf("Deleted cluster order '%s''\n", orderId)
+	c.deleted(orderId)
+
+	return nil
+}
+
+// listOrders retrieves the orders that match the filter, or all the orders if there is no filter.
+func (c *runnerContext) listOrders(ctx context.Context) (orders []*fulfillmentv1.ClusterOrder, err error) {
+	orders, err = paging.ListAll(ctx, func(ctx context.Context, offset, limit int32) ([]*fulfillmentv1.ClusterOrder,
+		int32, error) {
+		request := &fulfillmentv1.ClusterOrdersListRequest{
+			Offset: &offset,
+			Limit:  &limit,
+		}
+		if c.filter != "" {
+			request.Filter = &c.filter
+		}
+		response, err := c.client.List(ctx, request)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Items, response.GetTotal(), nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list orders: %w", err)
+	}
+	return
+}
+
+// readOrders reads the identifiers of the orders from the standard input, one per line, and retrieves them. The
+// lines can also contain the names written by the '-o name' option, like 'clusterorder/123'. Empty lines are
+// ignored.
+func (c *runnerContext) readOrders(ctx context.Context) (orders []*fulfillmentv1.ClusterOrder, err error) {
+	var ids []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var id string
+		id, err = output.ParseName("clusterorder", line)
+		if err != nil {
+			return
+		}
+		ids = append(ids, id)
+	}
+	err = scanner.Err()
+	if err != nil {
+		err = fmt.Errorf("failed to read cluster order IDs from the standard input: %w", err)
+		return
+	}
+	orders, err = paging.GetAll(ctx, ids, paging.DefaultConcurrency, func(ctx context.Context,
+		id string) (*fulfillmentv1.ClusterOrder, error) {
+		response, err := c.client.Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+			Id: id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return response.Object, nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+	return
+}
+
+// deleteMany deletes the given orders, after asking for confirmation.
+func (c *runnerContext) deleteMany(ctx context.Context, orders []*fulfillmentv1.ClusterOrder) error {
+	if len(orders) == 0 {
+		if c.output != "name" {
+			fmt.Printf("No cluster order was selected\n")
+		}
+		return nil
+	}
 
+	// Ask for confirmation, unless explicitly disabled or this is a client dry run:
+	if !c.mutation.Yes && !c.mutation.Client() {
+		fmt.Fprintf(os.Stderr, "The following cluster orders were selected:\n")
+		for _, order := range orders {
+			fmt.Fprintf(os.Stderr, "  %s\n", order.Id)
+		}
+		question := fmt.Sprintf("Delete %d cluster orders%s?", len(orders), c.mutation.Suffix())
+		confirmed, err := c.mutation.Confirm(question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintf(os.Stderr, "No cluster order was deleted\n")
+			return nil
+		}
+	}
+
+	// Delete the orders, continuing with the rest if one of them fails:
+	deleted := 0
+	for _, order := range orders {
+		err := c.delete(ctx, order)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to delete cluster order '%s': %v\n", order.Id, err)
+			continue
+		}
+		c.deleted(order.Id)
+		deleted++
+	}
+	if c.output != "name" {
+		fmt.Printf("Deleted %d of %d cluster orders%s\n", deleted, len(orders), c.mutation.Suffix())
+	}
+	if deleted < len(orders) {
+		return fmt.Errorf("failed to delete %d cluster orders", len(orders)-deleted)
+	}
+
+	return nil
+}
+
+// deleted reports that the order with the given identifier has been deleted.
+func (c *runnerContext) deleted(orderId string) {
+	if c.output == "name" {
+		output.Name(os.Stdout, "clusterorder", orderId)
+		return
+	}
+	fmt.Printf("Deleted cluster order '%s'%s\n", orderId, c.mutation.Suffix())
+}
+
+// delete deletes the given order, running the hooks that the user may have configured before and after that. In dry
+// runs the hooks aren't executed and nothing is sent to the server.
+func (c *runnerContext) delete(ctx context.Context, order *fulfillmentv1.ClusterOrder) error {
+	request := &fulfillmentv1.ClusterOrdersDeleteRequest{
+		Id: order.Id,
+	}
+	if c.mutation.Client() {
+		return nil
+	}
+	event := &hooks.Event{
+		Operation: hooks.OperationDelete,
+		Type:      "clusterorder",
+		ID:        order.Id,
+		Object:    order,
+	}
+	err := hooks.Pre(ctx, c.cfg, event)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.Delete(ctx, request)
+	if err != nil {
+		return err
+	}
+	hooks.Post(ctx, c.cfg, event)
 	return nil
 }
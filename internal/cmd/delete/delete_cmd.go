@@ -17,6 +17,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/innabox/fulfillment-cli/internal/cmd/delete/clusterorder"
+	"github.com/innabox/fulfillment-cli/internal/examples"
 )
 
 func Cmd() *cobra.Command {
@@ -25,5 +26,6 @@ func Cmd() *cobra.Command {
 		Short: "Delete resource",
 	}
 	result.AddCommand(clusterorder.Cmd())
+	examples.Collect(result)
 	return result
 }
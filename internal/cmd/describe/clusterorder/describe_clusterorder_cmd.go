@@ -16,13 +16,16 @@ package clusterorder
 import (
 	"fmt"
 	"os"
-	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/innabox/fulfillment-cli/internal/anonymize"
 	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
 	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/snapshots"
 )
 
 func Cmd() *cobra.Command {
@@ -31,25 +34,44 @@ func Cmd() *cobra.Command {
 		Use:     "clusterorder [flags] ID",
 		Aliases: []string{"clusterorders"},
 		Short:   "Describe a cluster order",
-		RunE:    runner.run,
+		Example: "" +
+			"  # Describe a cluster order:\n" +
+			"  fulfillment-cli describe clusterorder 123\n" +
+			"\n" +
+			"  # Extract the template of the order:\n" +
+			"  fulfillment-cli describe clusterorder 123 --jq '.spec.template_id'",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.First(completion.ClusterOrders),
 	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.jq,
+		"jq",
+		"",
+		"Process the JSON representation of the result with the given jq expression",
+	)
+	runner.anonymize = anonymize.AddFlags(flags)
 	return result
 }
 
 type runnerContext struct {
+	jq        string
+	anonymize *anonymize.Flags
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
-        // Check that there is exactly one cluster order ID specified
-        if len(args) != 1 {
-	        fmt.Fprintf(
-                        os.Stderr,
-                        "Expected exactly one cluster order ID\n",
-	        )
-                os.Exit(1)
-        }
+	// Check that there is exactly one cluster order ID specified
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one cluster order ID")
+	}
 	orderId := args[0]
 
+	// Replace the sensitive values with pseudonyms if requested:
+	err := c.anonymize.Apply()
+	if err != nil {
+		return err
+	}
+
 	// Get the context:
 	ctx := cmd.Context()
 
@@ -79,21 +101,36 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to describe order: %w", err)
 	}
 
+	// Save a snapshot of the order, so that the 'changes' command can later find what changed. Failing to do so
+	// shouldn't prevent displaying the result.
+	err = snapshots.Save("clusterorder", orderId, response.Object)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// If a jq expression was given then use it to process the result instead of displaying the table:
+	if c.jq != "" {
+		return output.Query(os.Stdout, c.jq, response.Object)
+	}
+
 	// Display the orders:
+	order, err := output.Process(response.Object)
+	if err != nil {
+		return err
+	}
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	order := response.Object
 	templateId := "-"
 	if order.Spec != nil {
-	   	templateId = order.Spec.TemplateId
+		templateId = order.Spec.TemplateId
 	}
 	state := "-"
 	if order.Status != nil {
-	        state = order.Status.State.String()
-		state = strings.Replace(state, "CLUSTER_ORDER_STATE_", "", -1)
+		state = output.Enum(order.Status.State)
 	}
 	fmt.Fprintf(writer, "ID:\t%s\n", order.Id)
 	fmt.Fprintf(writer, "Template:\t%s\n", templateId)
 	fmt.Fprintf(writer, "State:\t%s\n", state)
+	fmt.Fprintf(writer, "Created:\t%s\n", output.Timestamp(order.GetMetadata().GetCreationTimestamp()))
 	writer.Flush()
 
 	return nil
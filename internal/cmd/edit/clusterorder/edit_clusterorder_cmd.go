@@ -0,0 +1,255 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package clusterorder
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/hooks"
+	"github.com/innabox/fulfillment-cli/internal/mutation"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/patch"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "clusterorder [flags] ID",
+		Short: "Edit a cluster order",
+		Long: "Edit a cluster order applying a JSON merge patch, as described in RFC 7386, to its current " +
+			"version. Only the fields changed by the patch are sent to the server, using the update mask, so " +
+			"that fields changed at the same time by other clients aren't overwritten. Maps, like the template " +
+			"parameters, are replaced completely by the values given in the patch. Fields that the patch sets to " +
+			"their current values aren't sent, and if nothing changes the order isn't updated. Confirmation is " +
+			"requested before updating the order, unless the '--yes' flag is used.",
+		Example: "" +
+			"  # Change the template of a cluster order:\n" +
+			"  fulfillment-cli edit clusterorder 123 --patch '{\"spec\":{\"template_id\":\"ocp-large\"}}'\n" +
+			"\n" +
+			"  # Apply the patch contained in a YAML file:\n" +
+			"  fulfillment-cli edit clusterorder 123 --patch-file patch.yaml\n" +
+			"\n" +
+			"  # Check the result of the patch, without changing the order:\n" +
+			"  fulfillment-cli edit clusterorder 123 --patch-file patch.yaml --dry-run",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.First(completion.ClusterOrders),
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.patch,
+		"patch",
+		"",
+		"JSON merge patch to apply to the order, for example '{\"spec\":{\"template_id\":\"ocp-large\"}}'. YAML "+
+			"is also accepted.",
+	)
+	flags.StringVar(
+		&runner.patchFile,
+		"patch-file",
+		"",
+		"File containing the JSON or YAML merge patch to apply to the order, or '-' to read it from the standard "+
+			"input.",
+	)
+	result.MarkFlagsMutuallyExclusive("patch", "patch-file")
+	result.MarkFlagFilename("patch-file", "json", "yaml", "yml")
+	flags.StringVarP(
+		&runner.output,
+		"output",
+		"o",
+		"",
+		"Output format, one of 'json', 'yaml' or 'name'. The 'json' and 'yaml' formats write the complete order "+
+			"returned by the server, and 'name' writes only the type and identifier of the order, like "+
+			"'clusterorder/123'.",
+	)
+	runner.mutation = mutation.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	patch     string
+	patchFile string
+	output    string
+	mutation  *mutation.Flags
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the arguments and flags:
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one cluster order ID")
+	}
+	orderId := args[0]
+	if c.patch == "" && c.patchFile == "" {
+		return fmt.Errorf("one of the '--patch' or '--patch-file' flags is required")
+	}
+	err := c.mutation.Validate()
+	if err != nil {
+		return err
+	}
+	switch c.output {
+	case "", "json", "yaml", "name":
+	default:
+		return fmt.Errorf("output format '%s' isn't supported, it should be 'json', 'yaml' or 'name'", c.output)
+	}
+
+	// Read the patch:
+	changes, err := c.readPatch()
+	if err != nil {
+		return err
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Create the client for the cluster orders service:
+	client := fulfillmentv1.NewClusterOrdersClient(conn)
+
+	// Get the current version of the order and apply the patch:
+	getResponse, err := client.Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+		Id: orderId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	patched, paths, err := patch.Apply(getResponse.Object, changes)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if path == "id" {
+			return fmt.Errorf("the identifier of the order can't be changed")
+		}
+	}
+	order := patched.(*fulfillmentv1.ClusterOrder)
+
+	// If the patch doesn't change anything there is no need to send it to the server:
+	if len(paths) == 0 {
+		return c.display(order, "Cluster order '%s' not changed\n")
+	}
+
+	// In client dry runs display the order that would result, without sending it:
+	if c.mutation.Client() {
+		fmt.Printf(
+			"The following cluster order would be updated%s, changing '%s':\n",
+			c.mutation.Suffix(), strings.Join(paths, "', '"),
+		)
+		return output.JSON(os.Stdout, order)
+	}
+
+	// Ask for confirmation, unless explicitly disabled:
+	question := fmt.Sprintf("Update cluster order '%s', changing '%s'?", orderId, strings.Join(paths, "', '"))
+	confirmed, err := c.mutation.Confirm(question)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Fprintf(os.Stderr, "Cluster order '%s' not updated\n", orderId)
+		return nil
+	}
+
+	// Run the hook that the user may have configured to check the order before updating it:
+	err = hooks.Pre(ctx, cfg, &hooks.Event{
+		Operation: hooks.OperationUpdate,
+		Type:      "clusterorder",
+		ID:        orderId,
+		Object:    order,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Send only the fields changed by the patch:
+	updateResponse, err := client.Update(ctx, &fulfillmentv1.ClusterOrdersUpdateRequest{
+		Object: order,
+		UpdateMask: &fieldmaskpb.FieldMask{
+			Paths: paths,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+	order = updateResponse.Object
+	hooks.Post(ctx, cfg, &hooks.Event{
+		Operation: hooks.OperationUpdate,
+		Type:      "clusterorder",
+		ID:        order.Id,
+		Object:    order,
+	})
+
+	// Display the result:
+	return c.display(order, "Updated cluster order '%s'\n")
+}
+
+// display writes the order in the format selected with the '--output' flag. When no format was selected it writes
+// the given message, which should contain a '%s' for the identifier of the order.
+func (c *runnerContext) display(order *fulfillmentv1.ClusterOrder, message string) error {
+	switch c.output {
+	case "json":
+		return output.JSON(os.Stdout, order)
+	case "yaml":
+		return output.YAML(os.Stdout, order)
+	case "name":
+		return output.Name(os.Stdout, "clusterorder", order.Id)
+	default:
+		fmt.Printf(message, order.Id)
+		return nil
+	}
+}
+
+// readPatch reads the patch from the '--patch' flag or from the file given with the '--patch-file' flag.
+func (c *runnerContext) readPatch() (result map[string]any, err error) {
+	var data []byte
+	source := "flag '--patch'"
+	switch {
+	case c.patch != "":
+		data = []byte(c.patch)
+	case c.patchFile == "-":
+		source = "the standard input"
+		data, err = io.ReadAll(os.Stdin)
+	default:
+		source = fmt.Sprintf("file '%s'", c.patchFile)
+		data, err = os.ReadFile(c.patchFile)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read patch from %s: %w", source, err)
+		return
+	}
+	result, err = patch.Parse(data)
+	if err != nil {
+		err = fmt.Errorf("failed to parse patch from %s: %w", source, err)
+	}
+	return
+}
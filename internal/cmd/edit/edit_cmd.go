@@ -0,0 +1,31 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package edit
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/cmd/edit/clusterorder"
+	"github.com/innabox/fulfillment-cli/internal/examples"
+)
+
+func Cmd() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit resource",
+	}
+	result.AddCommand(clusterorder.Cmd())
+	examples.Collect(result)
+	return result
+}
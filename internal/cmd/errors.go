@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Exit codes of the tool. Scripts can use them to decide what to do when a command fails, so they shouldn't change.
+const (
+	// ExitError is used for errors that don't have a more specific exit code.
+	ExitError = 1
+
+	// ExitInvalidArgument is used when the server rejects a request because it isn't valid.
+	ExitInvalidArgument = 2
+
+	// ExitNotFound is used when the requested object doesn't exist.
+	ExitNotFound = 3
+
+	// ExitPermissionDenied is used when the user isn't authenticated or doesn't have permission.
+	ExitPermissionDenied = 4
+
+	// ExitUnavailable is used when the server can't be reached or doesn't respond in time.
+	ExitUnavailable = 5
+)
+
+// exitCodesHelp is the description of the exit codes that is added to the help of the root command.
+const exitCodesHelp = `Exit codes:
+  0  Success
+  1  Other errors
+  2  The server rejected the request as invalid
+  3  The object doesn't exist
+  4  Not authenticated, or permission denied
+  5  The server is unavailable or didn't respond in time`
+
+// Error formats supported by the '--error-format' flag:
+const (
+	errorFormatText = "text"
+	errorFormatJSON = "json"
+)
+
+// errorFormatFlag is the name of the flag that selects the format of the errors.
+const errorFormatFlag = "error-format"
+
+// ExitCode calculates the exit code that corresponds to the given error, using the gRPC status code if the error
+// contains one.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	grpcStatus, ok := status.FromError(err)
+	if !ok {
+		return ExitError
+	}
+	switch grpcStatus.Code() {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return ExitInvalidArgument
+	case codes.NotFound:
+		return ExitNotFound
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return ExitPermissionDenied
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ExitUnavailable
+	default:
+		return ExitError
+	}
+}
+
+// WriteError writes the error using the format selected with the '--error-format' flag of the given root command.
+func WriteError(root *cobra.Command, writer io.Writer, err error) {
+	format, _ := root.PersistentFlags().GetString(errorFormatFlag)
+	if format != errorFormatJSON {
+		fmt.Fprintf(writer, "Error: %s\n", err)
+		return
+	}
+	object := map[string]any{
+		"exit_code": ExitCode(err),
+		"message":   err.Error(),
+	}
+	grpcStatus, ok := status.FromError(err)
+	if ok {
+		object["code"] = grpcStatus.Code().String()
+		var details []any
+		for _, detail := range grpcStatus.Proto().GetDetails() {
+			data, err := protojson.Marshal(detail)
+			if err != nil {
+				continue
+			}
+			var value any
+			err = json.Unmarshal(data, &value)
+			if err != nil {
+				continue
+			}
+			details = append(details, value)
+		}
+		if details != nil {
+			object["details"] = details
+		}
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		fmt.Fprintf(writer, "Error: %s\n", err)
+		return
+	}
+	fmt.Fprintf(writer, "%s\n", data)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	eventsv1 "github.com/innabox/fulfillment-cli/internal/api/events/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/experimental"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/watch"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "events [flags] [OBJECT [ID]]",
+		Short: "Display events",
+		Long: "Display the events generated by the server when objects are created, updated or deleted. The " +
+			"events can be limited to one type of object, 'cluster', 'clusterorder' or 'clustertemplate', and " +
+			"to one object of that type. The server doesn't keep the history of the events, so only the events " +
+			"that happen while the command runs are displayed, and the '--follow' flag is required.",
+		Args:              cobra.MaximumNArgs(2),
+		RunE:              runner.run,
+		ValidArgsFunction: runner.complete,
+	}
+	flags := result.Flags()
+	flags.BoolVarP(
+		&runner.follow,
+		"follow",
+		"f",
+		false,
+		"Wait for new events and display them as they happen",
+	)
+	flags.StringVar(
+		&runner.filter,
+		"filter",
+		"",
+		"Additional CEL expression that the events must satisfy, for example "+
+			"\"event.type == EVENT_TYPE_OBJECT_DELETED\". The event is in the 'event' variable.",
+	)
+	experimental.MarkCommand(result)
+	return result
+}
+
+type runnerContext struct {
+	follow bool
+	filter string
+}
+
+// fields contains the names of the fields of the event that contain each type of object.
+var fields = map[string]string{
+	"cluster":          "cluster",
+	"clusters":         "cluster",
+	"clusterorder":     "cluster_order",
+	"clusterorders":    "cluster_order",
+	"clustertemplate":  "cluster_template",
+	"clustertemplates": "cluster_template",
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// There is no history, so following is the only thing that can be done:
+	if !c.follow {
+		return fmt.Errorf(
+			"the server doesn't keep the history of events, use the '--follow' flag to display new events as " +
+				"they happen",
+		)
+	}
+
+	// Calculate the filter:
+	filter := "true"
+	if len(args) > 0 {
+		field, ok := fields[args[0]]
+		if !ok {
+			return fmt.Errorf(
+				"unsupported object type '%s', valid types are 'cluster', 'clusterorder' and 'clustertemplate'",
+				args[0],
+			)
+		}
+		filter = fmt.Sprintf("has(event.%s)", field)
+		if len(args) > 1 {
+			filter = fmt.Sprintf("%s && event.%s.id == %s", filter, field, strconv.Quote(args[1]))
+		}
+	}
+	if c.filter != "" {
+		filter = fmt.Sprintf("(%s) && (%s)", filter, c.filter)
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Display the events as they arrive. The header is written immediately, so that the user knows that the command
+	// is waiting, and the widths of the columns are set so that they will usually not need to grow.
+	table := output.NewTable(
+		os.Stdout,
+		output.Column{Header: "TIME", Width: len(output.Time(time.Now()))},
+		output.Column{Header: "EVENT", Width: len("OBJECT_CREATED")},
+		output.Column{Header: "OBJECT", Width: len("clustertemplate")},
+		output.Column{Header: "ID", Width: 36},
+		output.Column{Header: "STATE"},
+	)
+	table.Write(nil)
+	return watch.Events(ctx, conn, filter, os.Stderr, func(event *eventsv1.Event) error {
+		table.Append(row(event))
+		return nil
+	})
+}
+
+// complete suggests the object types for the first argument, and the identifiers of the objects of that type for the
+// second.
+func (c *runnerContext) complete(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return []string{"cluster", "clusterorder", "clustertemplate"}, cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		switch args[0] {
+		case "cluster", "clusters":
+			return completion.Clusters(cmd, args, toComplete)
+		case "clusterorder", "clusterorders":
+			return completion.ClusterOrders(cmd, args, toComplete)
+		case "clustertemplate", "clustertemplates":
+			return completion.ClusterTemplates(cmd, args, toComplete)
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// row calculates the values of the columns of the table for the given event. The server doesn't send the time of the
+// events, so the time when they are received is used instead.
+func row(event *eventsv1.Event) []string {
+	kind := "-"
+	id := "-"
+	state := "-"
+	switch {
+	case event.GetCluster() != nil:
+		kind = "cluster"
+		id = event.GetCluster().GetId()
+		if event.GetCluster().GetStatus() != nil {
+			state = output.Enum(event.GetCluster().GetStatus().GetState())
+		}
+	case event.GetClusterOrder() != nil:
+		kind = "clusterorder"
+		id = event.GetClusterOrder().GetId()
+		if event.GetClusterOrder().GetStatus() != nil {
+			state = output.Enum(event.GetClusterOrder().GetStatus().GetState())
+		}
+	case event.GetClusterTemplate() != nil:
+		kind = "clustertemplate"
+		id = event.GetClusterTemplate().GetId()
+	}
+	return []string{
+		output.Time(time.Now()),
+		output.Enum(event.GetType()),
+		kind,
+		id,
+		state,
+	}
+}
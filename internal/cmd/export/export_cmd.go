@@ -0,0 +1,357 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/innabox/fulfillment-cli/internal/anonymize"
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paging"
+	"github.com/innabox/fulfillment-cli/internal/reflection"
+	"github.com/innabox/fulfillment-cli/internal/terminal"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "export [flags]",
+		Short: "Export all the objects of a type",
+		Long: "Export all the objects of a type in newline delimited JSON format, one object per line. The " +
+			"objects are retrieved one page at a time and each page is written before requesting the next, so " +
+			"large inventories can be exported without keeping them in memory. When writing to a file the " +
+			"result is first written to a temporary file that replaces the target only when the export succeeds. " +
+			"When the output is a directory each object is written to its own file, inside a subdirectory for " +
+			"each type, without the 'status' and 'metadata' fields assigned by the server, so that the objects " +
+			"can be created again in another environment with the 'import' command.",
+		Example: "" +
+			"  # Export the cluster orders to a file:\n" +
+			"  fulfillment-cli export --type clusterorders --output orders.json\n" +
+			"\n" +
+			"  # Export the templates and orders to a directory, to import them in another environment:\n" +
+			"  fulfillment-cli export --type clustertemplates,clusterorders --output backup/",
+		RunE: runner.run,
+		Annotations: map[string]string{
+			output.SensitiveAnnotation: "true",
+		},
+	}
+	flags := result.Flags()
+	flags.StringSliceVar(
+		&runner.kinds,
+		"type",
+		nil,
+		"Types of the objects to export, 'clusters', 'clusterorders' or 'clustertemplates'. Several types "+
+			"separated by commas can be exported when the output is a directory.",
+	)
+	flags.StringVar(
+		&runner.filter,
+		"filter",
+		"",
+		"Export only the objects that match the given filter, for example \"state = 'FULFILLED'\". The filter is "+
+			"evaluated by the server.",
+	)
+	flags.StringVar(
+		&runner.output,
+		"output",
+		"-",
+		"File where the objects will be written, or '-' for the standard output. If it is a directory, or ends "+
+			"with a slash, each object is written to its own file in that directory.",
+	)
+	flags.Int32Var(
+		&runner.pageSize,
+		"page-size",
+		paging.DefaultSize,
+		"Number of objects requested in each page. Reduce it if the responses exceed the maximum message size.",
+	)
+	runner.anonymize = anonymize.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	kinds     []string
+	filter    string
+	output    string
+	pageSize  int32
+	anonymize *anonymize.Flags
+	spinner   *terminal.Spinner
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the flags:
+	if len(c.kinds) == 0 {
+		return fmt.Errorf("flag '--type' is mandatory")
+	}
+	kinds := make([]string, len(c.kinds))
+	for i, kind := range c.kinds {
+		kinds[i] = normalizeKind(kind)
+		if kinds[i] == "" {
+			return fmt.Errorf(
+				"unsupported object type '%s', valid types are 'clusters', 'clusterorders' and 'clustertemplates'",
+				kind,
+			)
+		}
+	}
+	directory := strings.HasSuffix(c.output, "/")
+	info, err := os.Stat(c.output)
+	if err == nil && info.IsDir() {
+		directory = true
+	}
+	if !directory && len(kinds) > 1 {
+		return fmt.Errorf("exporting several types requires a directory as output, for example '--output backup/'")
+	}
+	if c.pageSize <= 0 {
+		return fmt.Errorf("page size should be positive, but it is %d", c.pageSize)
+	}
+
+	// Replace the sensitive values with pseudonyms if requested:
+	err = c.anonymize.Apply()
+	if err != nil {
+		return err
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Write to a directory, to the standard output, or to a temporary file that will be renamed when the export
+	// finishes:
+	if directory {
+		return c.exportDir(ctx, conn, kinds)
+	}
+	kind := kinds[0]
+	if c.output == "-" {
+		_, err = c.exportFile(ctx, conn, kind, os.Stdout)
+		return err
+	}
+	dir := filepath.Dir(c.output)
+	tmp, err := os.CreateTemp(dir, filepath.Base(c.output)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file in '%s': %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+	c.spinner = terminal.StartSpinner(os.Stderr, fmt.Sprintf("Exporting %s", kind))
+	count, err := c.exportFile(ctx, conn, kind, tmp)
+	c.spinner.Stop()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close temporary file '%s': %w", tmp.Name(), err)
+	}
+	err = os.Rename(tmp.Name(), c.output)
+	if err != nil {
+		return fmt.Errorf("failed to rename '%s' to '%s': %w", tmp.Name(), c.output, err)
+	}
+	terminal.Infof(os.Stderr, "Exported %d %s to '%s'\n", count, kind, c.output)
+
+	return nil
+}
+
+// exportFile writes all the objects of the given type to the given writer and returns the number of objects written.
+func (c *runnerContext) exportFile(ctx context.Context, conn *grpc.ClientConn, kind string,
+	writer io.Writer) (count int, err error) {
+	buffered := bufio.NewWriter(writer)
+	count, err = c.export(ctx, conn, kind, func(object proto.Message) error {
+		return output.JSONLine(buffered, object)
+	})
+	if err != nil {
+		return
+	}
+	err = buffered.Flush()
+	if err != nil {
+		err = fmt.Errorf("failed to write objects: %w", err)
+	}
+	return
+}
+
+// exportDir writes each object of the given types to its own file, named after its identifier, inside a subdirectory
+// of the output directory named after its type. The fields assigned by the server are removed, so that the objects can
+// be created again with the 'import' command.
+func (c *runnerContext) exportDir(ctx context.Context, conn *grpc.ClientConn, kinds []string) error {
+	for _, kind := range kinds {
+		dir := filepath.Join(c.output, kind)
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create directory '%s': %w", dir, err)
+		}
+		c.spinner = terminal.StartSpinner(os.Stderr, fmt.Sprintf("Exporting %s", kind))
+		count, err := c.export(ctx, conn, kind, func(object proto.Message) error {
+			return exportObject(dir, object)
+		})
+		c.spinner.Stop()
+		if err != nil {
+			return err
+		}
+		terminal.Infof(os.Stderr, "Exported %d %s to '%s'\n", count, kind, dir)
+	}
+	return nil
+}
+
+// exportObject writes the object to a file in the given directory, without the fields assigned by the server. The file
+// is only readable by the current user, as objects may contain sensitive data.
+func exportObject(dir string, object proto.Message) error {
+	object = proto.Clone(object)
+	reflection.Clear(object, "status", "metadata")
+
+	// The name of the file is calculated from the processed object, so that it uses the anonymized identifier if
+	// requested. The object is processed again when it is written.
+	processed, err := output.Process(object)
+	if err != nil {
+		return err
+	}
+	identified, ok := processed.(output.Identified)
+	if !ok || identified.GetId() == "" || strings.ContainsAny(identified.GetId(), `/\`) {
+		return fmt.Errorf("object doesn't have an identifier that can be used as file name")
+	}
+	file := filepath.Join(dir, identified.GetId()+".json")
+	writer, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create file '%s': %w", file, err)
+	}
+	err = output.JSON(writer, object)
+	if err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write file '%s': %w", file, err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close file '%s': %w", file, err)
+	}
+	return nil
+}
+
+// export passes all the objects of the given type that match the filter to the given function, and returns the number
+// of objects.
+func (c *runnerContext) export(ctx context.Context, conn *grpc.ClientConn, kind string,
+	write func(proto.Message) error) (count int, err error) {
+	var filter *string
+	if c.filter != "" {
+		filter = &c.filter
+	}
+	switch kind {
+	case "clusters":
+		client := fulfillmentv1.NewClustersClient(conn)
+		count, err = exportPages(ctx, c.pageSize, c.spinner, write, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.Cluster, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClustersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+				Filter: filter,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+	case "clusterorders":
+		client := fulfillmentv1.NewClusterOrdersClient(conn)
+		count, err = exportPages(ctx, c.pageSize, c.spinner, write, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.ClusterOrder, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterOrdersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+				Filter: filter,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+	case "clustertemplates":
+		client := fulfillmentv1.NewClusterTemplatesClient(conn)
+		count, err = exportPages(ctx, c.pageSize, c.spinner, write, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.ClusterTemplate, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+				Filter: filter,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+	}
+	return
+}
+
+// normalizeKind returns the plural name of the given object type, which is also the name of the subdirectory used
+// when exporting to a directory, or an empty string if the type isn't supported.
+func normalizeKind(kind string) string {
+	switch kind {
+	case "cluster", "clusters":
+		return "clusters"
+	case "clusterorder", "clusterorders":
+		return "clusterorders"
+	case "clustertemplate", "clustertemplates":
+		return "clustertemplates"
+	default:
+		return ""
+	}
+}
+
+// exportPages retrieves the pages of objects using the given function and passes each object to the write function as
+// soon as its page is received. The spinner, if any, is updated with the number of objects written after each page.
+func exportPages[T proto.Message](ctx context.Context, size int32, spinner *terminal.Spinner,
+	write func(proto.Message) error, fetch paging.FetchFunc[T]) (count int, err error) {
+	pager := paging.NewPager(fetch, size)
+	for {
+		var items []T
+		items, err = pager.Next(ctx)
+		if err != nil {
+			err = fmt.Errorf("failed to list objects: %w", err)
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+		for _, item := range items {
+			err = write(item)
+			if err != nil {
+				err = fmt.Errorf("failed to write object: %w", err)
+				return
+			}
+			count++
+		}
+		spinner.Updatef("Exported %d objects", count)
+	}
+}
@@ -14,80 +14,112 @@ language governing permissions and limitations under the License.
 package cluster
 
 import (
-	"fmt"
-	"os"
-	"text/tabwriter"
+	"context"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 
+	eventsv1 "github.com/innabox/fulfillment-cli/internal/api/events/v1"
 	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
-	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/completion"
+	"github.com/innabox/fulfillment-cli/internal/listing"
+	"github.com/innabox/fulfillment-cli/internal/output"
 )
 
 func Cmd() *cobra.Command {
-	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "cluster [flags]",
+		Use:     "cluster [flags] [ID...]",
 		Aliases: []string{"clusters"},
 		Short:   "Get clusters",
-		RunE:    runner.run,
+		Example: "" +
+			"  # List the clusters, including the creation time:\n" +
+			"  fulfillment-cli get clusters -o wide\n" +
+			"\n" +
+			"  # List the clusters sorted by creation time, displaying only some columns:\n" +
+			"  fulfillment-cli get clusters --sort-by 'cluster.metadata.creation_timestamp' \\\n" +
+			"    --columns 'ID,STATE,API URL'\n" +
+			"\n" +
+			"  # Write the clusters to a file in JSON format, while displaying the table:\n" +
+			"  fulfillment-cli get clusters -o table -o json=clusters.json\n" +
+			"\n" +
+			"  # Get some of the clusters by identifier:\n" +
+			"  fulfillment-cli get clusters 123 456",
+		ValidArgsFunction: completion.Clusters,
 	}
+	listing.Setup(result, &listing.Kind[*fulfillmentv1.Cluster]{
+		Name:           "cluster",
+		Plural:         "clusters",
+		Variable:       "cluster",
+		Message:        &fulfillmentv1.Cluster{},
+		Event:          "cluster",
+		ColumnsExample: "ID,STATE,URL=cluster.status.api_url",
+		SortExample:    "cluster.metadata.creation_timestamp",
+		Columns: []output.Column{
+			{Header: "ID"},
+			{Header: "STATE"},
+			{Header: "API URL", Max: 60},
+			{Header: "CONSOLE URL", Max: 60},
+			{Header: "AGE"},
+			{Header: "CREATED", Wide: true},
+		},
+		Row:       row,
+		List:      list,
+		Get:       get,
+		FromEvent: fromEvent,
+	})
 	return result
 }
 
-type runnerContext struct {
-}
-
-func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
-	// Get the context:
-	ctx := cmd.Context()
-
-	// Get the configuration:
-	cfg, err := config.Load()
-	if err != nil {
-		return err
+// row calculates the values of the default columns for the given cluster.
+func row(cluster *fulfillmentv1.Cluster) []string {
+	state := "-"
+	apiUrl := "-"
+	consoleUrl := "-"
+	if cluster.Status != nil {
+		state = output.Enum(cluster.Status.State)
+		apiUrl = cluster.Status.GetApiUrl()
+		consoleUrl = cluster.Status.GetConsoleUrl()
 	}
-	if cfg.Address == "" {
-		return fmt.Errorf("there is no configuration, run the 'login' command")
+	return []string{
+		cluster.Id,
+		state,
+		apiUrl,
+		consoleUrl,
+		output.Age(cluster.GetMetadata().GetCreationTimestamp()),
+		output.Timestamp(cluster.GetMetadata().GetCreationTimestamp()),
 	}
+}
 
-	// Create the gRPC connection from the configuration:
-	conn, err := cfg.Connect()
+// list retrieves one page of clusters.
+func list(ctx context.Context, conn *grpc.ClientConn, offset, limit int32) (items []*fulfillmentv1.Cluster,
+	total int32, err error) {
+	response, err := fulfillmentv1.NewClustersClient(conn).List(ctx, &fulfillmentv1.ClustersListRequest{
+		Offset: &offset,
+		Limit:  &limit,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create gRPC connection: %w", err)
+		return
 	}
+	items = response.Items
+	total = response.GetTotal()
+	return
+}
 
-	// Create the client for the clusters service:
-	client := fulfillmentv1.NewClustersClient(conn)
-
-	// Get the list of clusters:
-	response, err := client.List(ctx, &fulfillmentv1.ClustersListRequest{})
+// get retrieves one cluster.
+func get(ctx context.Context, conn *grpc.ClientConn, id string) (result *fulfillmentv1.Cluster, err error) {
+	response, err := fulfillmentv1.NewClustersClient(conn).Get(ctx, &fulfillmentv1.ClustersGetRequest{
+		Id: id,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to list clusters: %w", err)
+		return
 	}
+	result = response.Object
+	return
+}
 
-	// Display the clusters:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tSTATE\tAPI URL\tCONSOLE URL\n")
-	for _, cluster := range response.Items {
-		state := "-"
-		apiUrl := "-"
-		consoleUrl := "-"
-		if cluster.Status != nil {
-			state = cluster.Status.State.String()
-			apiUrl = cluster.Status.GetApiUrl()
-			consoleUrl = cluster.Status.GetConsoleUrl()
-		}
-		fmt.Fprintf(
-			writer,
-			"%s\t%s\t%s\t%s\n",
-			cluster.Id,
-			state,
-			apiUrl,
-			consoleUrl,
-		)
-	}
-	writer.Flush()
-
-	return nil
+// fromEvent returns the cluster contained in the event, if any.
+func fromEvent(event *eventsv1.Event) (result *fulfillmentv1.Cluster, ok bool) {
+	result = event.GetCluster()
+	ok = result != nil
+	return
 }
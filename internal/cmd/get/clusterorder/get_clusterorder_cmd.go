@@ -14,84 +14,116 @@ language governing permissions and limitations under the License.
 package clusterorder
 
 import (
-	"fmt"
-	"os"
-	"strings"
-	"text/tabwriter"
+	"context"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 
+	eventsv1 "github.com/innabox/fulfillment-cli/internal/api/events/v1"
 	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
-	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/completion"
+	"github.com/innabox/fulfillment-cli/internal/listing"
+	"github.com/innabox/fulfillment-cli/internal/output"
 )
 
 func Cmd() *cobra.Command {
-	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "clusterorder [flags]",
+		Use:     "clusterorder [flags] [ID...]",
 		Aliases: []string{"clusterorders"},
 		Short:   "Get cluster orders",
-		RunE:    runner.run,
+		Example: "" +
+			"  # List the cluster orders:\n" +
+			"  fulfillment-cli get clusterorders\n" +
+			"\n" +
+			"  # Display only the identifiers and templates of the orders:\n" +
+			"  fulfillment-cli get clusterorders --columns 'ID,TEMPLATE ID'\n" +
+			"\n" +
+			"  # Write the names of the orders, for use with other commands:\n" +
+			"  fulfillment-cli get clusterorders -o name\n" +
+			"\n" +
+			"  # Get some of the cluster orders by identifier:\n" +
+			"  fulfillment-cli get clusterorders 123 456",
+		ValidArgsFunction: completion.ClusterOrders,
+		Annotations: map[string]string{
+			output.SensitiveAnnotation: "true",
+		},
 	}
+	listing.Setup(result, &listing.Kind[*fulfillmentv1.ClusterOrder]{
+		Name:           "clusterorder",
+		Plural:         "cluster orders",
+		Variable:       "order",
+		Message:        &fulfillmentv1.ClusterOrder{},
+		Event:          "cluster_order",
+		ColumnsExample: "ID,STATE,TEMPLATE=order.spec.template_id",
+		SortExample:    "order.status.state",
+		Columns: []output.Column{
+			{Header: "ID"},
+			{Header: "TEMPLATE ID"},
+			{Header: "STATE"},
+			{Header: "CLUSTER ID"},
+			{Header: "AGE"},
+			{Header: "CREATED", Wide: true},
+		},
+		Row:       row,
+		List:      list,
+		Get:       get,
+		FromEvent: fromEvent,
+	})
 	return result
 }
 
-type runnerContext struct {
-}
-
-func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
-	// Get the context:
-	ctx := cmd.Context()
-
-	// Get the configuration:
-	cfg, err := config.Load()
-	if err != nil {
-		return err
+// row calculates the values of the default columns for the given order.
+func row(order *fulfillmentv1.ClusterOrder) []string {
+	templateId := "-"
+	if order.Spec != nil {
+		templateId = order.Spec.TemplateId
 	}
-	if cfg.Address == "" {
-		return fmt.Errorf("there is no configuration, run the 'login' command")
+	state := "-"
+	clusterId := "-"
+	if order.Status != nil {
+		state = output.Enum(order.Status.State)
+		clusterId = order.Status.GetClusterId()
 	}
-
-	// Create the gRPC connection from the configuration:
-	conn, err := cfg.Connect()
-	if err != nil {
-		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	return []string{
+		order.Id,
+		templateId,
+		state,
+		clusterId,
+		output.Age(order.GetMetadata().GetCreationTimestamp()),
+		output.Timestamp(order.GetMetadata().GetCreationTimestamp()),
 	}
+}
 
-	// Create the client for the cluster orders service:
-	client := fulfillmentv1.NewClusterOrdersClient(conn)
-
-	// Get the list of orders:
-	response, err := client.List(ctx, &fulfillmentv1.ClusterOrdersListRequest{})
+// list retrieves one page of orders.
+func list(ctx context.Context, conn *grpc.ClientConn, offset, limit int32) (items []*fulfillmentv1.ClusterOrder,
+	total int32, err error) {
+	response, err := fulfillmentv1.NewClusterOrdersClient(conn).List(ctx, &fulfillmentv1.ClusterOrdersListRequest{
+		Offset: &offset,
+		Limit:  &limit,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to list orders: %w", err)
+		return
 	}
+	items = response.Items
+	total = response.GetTotal()
+	return
+}
 
-	// Display the orders:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tTEMPLATE ID\tSTATE\tCLUSTER ID\n")
-	for _, order := range response.Items {
-		templateId := "-"
-		if order.Spec != nil {
-			templateId = order.Spec.TemplateId
-		}
-		state := "-"
-		clusterId := "-"
-		if order.Status != nil {
-			state = order.Status.State.String()
-			state = strings.Replace(state, "CLUSTER_ORDER_STATE_", "", -1)
-			clusterId = order.Status.GetClusterId()
-		}
-		fmt.Fprintf(
-			writer,
-			"%s\t%s\t%s\t%s\n",
-			order.Id,
-			templateId,
-			state,
-			clusterId,
-		)
+// get retrieves one order.
+func get(ctx context.Context, conn *grpc.ClientConn, id string) (result *fulfillmentv1.ClusterOrder, err error) {
+	response, err := fulfillmentv1.NewClusterOrdersClient(conn).Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+		Id: id,
+	})
+	if err != nil {
+		return
 	}
-	writer.Flush()
+	result = response.Object
+	return
+}
 
-	return nil
+// fromEvent returns the order contained in the event, if any.
+func fromEvent(event *eventsv1.Event) (result *fulfillmentv1.ClusterOrder, ok bool) {
+	result = event.GetClusterOrder()
+	ok = result != nil
+	return
 }
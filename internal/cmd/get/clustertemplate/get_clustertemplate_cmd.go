@@ -14,71 +14,107 @@ language governing permissions and limitations under the License.
 package clustertemplate
 
 import (
-	"fmt"
-	"os"
-	"text/tabwriter"
+	"context"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 
+	eventsv1 "github.com/innabox/fulfillment-cli/internal/api/events/v1"
 	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
-	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/completion"
+	"github.com/innabox/fulfillment-cli/internal/listing"
+	"github.com/innabox/fulfillment-cli/internal/output"
 )
 
 func Cmd() *cobra.Command {
-	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "clustertemplate [flags]",
+		Use:     "clustertemplate [flags] [ID...]",
 		Aliases: []string{"clustertemplates"},
 		Short:   "Get cluster templates",
-		RunE:    runner.run,
+		Example: "" +
+			"  # List the cluster templates:\n" +
+			"  fulfillment-cli get clustertemplates\n" +
+			"\n" +
+			"  # Display the complete templates, including the parameters:\n" +
+			"  fulfillment-cli get clustertemplates -o yaml\n" +
+			"\n" +
+			"  # Get some of the cluster templates by identifier:\n" +
+			"  fulfillment-cli get clustertemplates 123 456",
+		ValidArgsFunction: completion.ClusterTemplates,
 	}
+	listing.Setup(result, &listing.Kind[*fulfillmentv1.ClusterTemplate]{
+		Name:           "clustertemplate",
+		Plural:         "cluster templates",
+		Variable:       "template",
+		Message:        &fulfillmentv1.ClusterTemplate{},
+		Event:          "cluster_template",
+		ColumnsExample: "ID,PARAMETERS=size(template.parameters)",
+		SortExample:    "template.title",
+		Columns: []output.Column{
+			{Header: "ID"},
+			{Header: "TITLE", Max: 40},
+			{Header: "DESCRIPTION", Max: 60},
+			{Header: "PARAMETERS", Wide: true, Max: 60},
+			{Header: "AGE"},
+			{Header: "CREATED", Wide: true},
+		},
+		Row:       row,
+		List:      list,
+		Get:       get,
+		FromEvent: fromEvent,
+	})
 	return result
 }
 
-type runnerContext struct {
-}
-
-func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
-	// Get the context:
-	ctx := cmd.Context()
-
-	// Get the configuration:
-	cfg, err := config.Load()
-	if err != nil {
-		return err
+// row calculates the values of the default columns for the given template.
+func row(template *fulfillmentv1.ClusterTemplate) []string {
+	parameters := make([]string, len(template.Parameters))
+	for i, parameter := range template.Parameters {
+		parameters[i] = parameter.Name
 	}
-	if cfg.Address == "" {
-		return fmt.Errorf("there is no configuration, run the 'login' command")
+	return []string{
+		template.Id,
+		template.Title,
+		template.Description,
+		strings.Join(parameters, ","),
+		output.Age(template.GetMetadata().GetCreationTimestamp()),
+		output.Timestamp(template.GetMetadata().GetCreationTimestamp()),
 	}
+}
 
-	// Create the gRPC connection from the configuration:
-	conn, err := cfg.Connect()
+// list retrieves one page of templates.
+func list(ctx context.Context, conn *grpc.ClientConn, offset, limit int32) (items []*fulfillmentv1.ClusterTemplate,
+	total int32, err error) {
+	client := fulfillmentv1.NewClusterTemplatesClient(conn)
+	response, err := client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+		Offset: &offset,
+		Limit:  &limit,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create gRPC connection: %w", err)
+		return
 	}
+	items = response.Items
+	total = response.GetTotal()
+	return
+}
 
-	// Create the client for the templates service:
+// get retrieves one template.
+func get(ctx context.Context, conn *grpc.ClientConn, id string) (result *fulfillmentv1.ClusterTemplate, err error) {
 	client := fulfillmentv1.NewClusterTemplatesClient(conn)
-
-	// Get the list of templates:
-	response, err := client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{})
+	response, err := client.Get(ctx, &fulfillmentv1.ClusterTemplatesGetRequest{
+		Id: id,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to list templates: %w", err)
+		return
 	}
+	result = response.Object
+	return
+}
 
-	// Display the templates:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tTITLE\tDESCRIPTION\n")
-	for _, template := range response.Items {
-		fmt.Fprintf(
-			writer,
-			"%s\t%s\t%s\n",
-			template.Id,
-			template.Title,
-			template.Description,
-		)
-	}
-	writer.Flush()
-
-	return nil
+// fromEvent returns the template contained in the event, if any.
+func fromEvent(event *eventsv1.Event) (result *fulfillmentv1.ClusterTemplate, ok bool) {
+	result = event.GetClusterTemplate()
+	ok = result != nil
+	return
 }
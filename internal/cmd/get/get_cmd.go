@@ -19,6 +19,7 @@ import (
 	"github.com/innabox/fulfillment-cli/internal/cmd/get/cluster"
 	"github.com/innabox/fulfillment-cli/internal/cmd/get/clusterorder"
 	"github.com/innabox/fulfillment-cli/internal/cmd/get/clustertemplate"
+	"github.com/innabox/fulfillment-cli/internal/examples"
 )
 
 func Cmd() *cobra.Command {
@@ -29,5 +30,6 @@ func Cmd() *cobra.Command {
 	result.AddCommand(cluster.Cmd())
 	result.AddCommand(clusterorder.Cmd())
 	result.AddCommand(clustertemplate.Cmd())
+	examples.Collect(result)
 	return result
 }
@@ -14,40 +14,114 @@ language governing permissions and limitations under the License.
 package cluster
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
 	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/kubeconfig"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paging"
 )
 
+// maxConcurrentDownloads is the maximum number of kubeconfigs that will be downloaded at the same time when using the
+// '--all-ready' flag.
+const maxConcurrentDownloads = 8
+
 func Cmd() *cobra.Command {
 	runner := &runnerContext{}
 	result := &cobra.Command{
-		Use:     "cluster [flags] ID",
-		Aliases: []string{"clusters"},
-		Short:   "Retrieve a cluster kubeconfig",
-		RunE:    runner.run,
+		Use:               "cluster [flags] ID",
+		Aliases:           []string{"clusters"},
+		Short:             "Retrieve a cluster kubeconfig",
+		RunE:              runner.run,
+		ValidArgsFunction: completion.First(completion.Clusters),
+		Annotations: map[string]string{
+			output.SensitiveAnnotation: "true",
+		},
 	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.allReady,
+		"all-ready",
+		false,
+		"Retrieve the kubeconfigs of all the clusters that are ready. Requires the '--dir' flag.",
+	)
+	flags.StringVar(
+		&runner.dir,
+		"dir",
+		"",
+		"Directory where the kubeconfigs will be written, in files named after the cluster identifiers",
+	)
+	flags.StringVar(
+		&runner.output,
+		"output",
+		"",
+		"File where the kubeconfig will be written. When used with '--merge' this is the file that the "+
+			"kubeconfig will be merged into.",
+	)
+	flags.BoolVar(
+		&runner.merge,
+		"merge",
+		false,
+		"Merge the clusters, users and contexts of the kubeconfig into an existing kubeconfig file, by default "+
+			"the one used by the Kubernetes tools. Entries whose names collide with different existing entries "+
+			"are renamed adding the cluster identifier, replacing the entries renamed by previous merges of the "+
+			"same cluster.",
+	)
+	flags.BoolVar(
+		&runner.switchContext,
+		"switch-context",
+		false,
+		"After merging the kubeconfig make its context the current one. Requires the '--merge' flag.",
+	)
 	return result
 }
 
 type runnerContext struct {
+	allReady      bool
+	dir           string
+	output        string
+	merge         bool
+	switchContext bool
+}
+
+// download contains the details of the download of the kubeconfig of one cluster.
+type download struct {
+	clusterId string
+	file      string
+	err       error
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
-        // Check that there is exactly one cluster ID specified
-        if len(args) != 1 {
-	        fmt.Fprintf(
-                        os.Stderr,
-                        "Expected exactly one cluster ID\n",
-	        )
-                os.Exit(1)
-        }
-	clusterId := args[0]
+	// Check the arguments. When retrieving the kubeconfigs of all the ready clusters there should be no cluster
+	// identifier, and otherwise there should be exactly one.
+	if c.allReady {
+		if len(args) != 0 {
+			return fmt.Errorf("cluster identifiers can't be used together with '--all-ready'")
+		}
+		if c.dir == "" {
+			return fmt.Errorf("flag '--dir' is mandatory when using '--all-ready'")
+		}
+		if c.output != "" || c.merge {
+			return fmt.Errorf("flags '--output' and '--merge' can't be used together with '--all-ready'")
+		}
+	} else if len(args) != 1 {
+		return fmt.Errorf("expected exactly one cluster ID")
+	}
+	if c.dir != "" && (c.output != "" || c.merge) {
+		return fmt.Errorf("flag '--dir' can't be used together with '--output' or '--merge'")
+	}
+	if c.switchContext && !c.merge {
+		return fmt.Errorf("flag '--switch-context' requires '--merge'")
+	}
 
 	// Get the context:
 	ctx := cmd.Context()
@@ -70,12 +144,42 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Create the client for the clusters service:
 	client := fulfillmentv1.NewClustersClient(conn)
 
+	if c.allReady {
+		return c.downloadAllReady(ctx, client)
+	}
+	clusterId := args[0]
+
 	// Get the kubeconfig:
 	response, err := client.GetKubeconfig(ctx, &fulfillmentv1.ClustersGetKubeconfigRequest{
 		Id: clusterId,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to describe order: %w", err)
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	// Write it to a file if a directory was given:
+	if c.dir != "" {
+		file, err := c.writeKubeconfig(clusterId, response.Kubeconfig)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Kubeconfig of cluster '%s' written to '%s'\n", clusterId, file)
+		return nil
+	}
+
+	// Merge it into an existing kubeconfig if requested:
+	if c.merge {
+		return c.mergeKubeconfig(clusterId, response.Kubeconfig)
+	}
+
+	// Write it to the output file if requested:
+	if c.output != "" {
+		err = os.WriteFile(c.output, []byte(response.Kubeconfig), 0600)
+		if err != nil {
+			return fmt.Errorf("failed to write kubeconfig '%s': %w", c.output, err)
+		}
+		fmt.Printf("Kubeconfig of cluster '%s' written to '%s'\n", clusterId, c.output)
+		return nil
 	}
 
 	// Display the orders:
@@ -85,3 +189,124 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// downloadAllReady downloads the kubeconfigs of all the clusters that are ready, and then displays a summary.
+func (c *runnerContext) downloadAllReady(ctx context.Context, client fulfillmentv1.ClustersClient) error {
+	// Get the list of clusters and select the ones that are ready:
+	clusters, err := paging.ListAll(ctx, func(ctx context.Context, offset, limit int32) ([]*fulfillmentv1.Cluster,
+		int32, error) {
+		response, err := client.List(ctx, &fulfillmentv1.ClustersListRequest{
+			Offset: &offset,
+			Limit:  &limit,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Items, response.GetTotal(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	var downloads []*download
+	for _, cluster := range clusters {
+		if cluster.GetStatus().GetState() != fulfillmentv1.ClusterState_CLUSTER_STATE_READY {
+			continue
+		}
+		downloads = append(downloads, &download{
+			clusterId: cluster.Id,
+		})
+	}
+	if len(downloads) == 0 {
+		fmt.Printf("There are no ready clusters\n")
+		return nil
+	}
+
+	// Download the kubeconfigs concurrently, limiting the number of simultaneous requests:
+	err = os.MkdirAll(c.dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("failed to create directory '%s': %w", c.dir, err)
+	}
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentDownloads)
+	for _, item := range downloads {
+		wg.Add(1)
+		go func(item *download) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			response, err := client.GetKubeconfig(ctx, &fulfillmentv1.ClustersGetKubeconfigRequest{
+				Id: item.clusterId,
+			})
+			if err != nil {
+				item.err = err
+				return
+			}
+			item.file, item.err = c.writeKubeconfig(item.clusterId, response.Kubeconfig)
+		}(item)
+	}
+	wg.Wait()
+
+	// Display the summary:
+	failed := 0
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CLUSTER ID\tFILE\tRESULT\n")
+	for _, item := range downloads {
+		file := item.file
+		if file == "" {
+			file = "-"
+		}
+		result := "OK"
+		if item.err != nil {
+			result = item.err.Error()
+			failed++
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", item.clusterId, file, result)
+	}
+	writer.Flush()
+	if failed > 0 {
+		return fmt.Errorf("failed to retrieve %d of %d kubeconfigs", failed, len(downloads))
+	}
+
+	return nil
+}
+
+// mergeKubeconfig merges the kubeconfig of a cluster into the file given with the '--output' flag or, if not given, the
+// default kubeconfig file.
+func (c *runnerContext) mergeKubeconfig(clusterId, source string) error {
+	file := c.output
+	if file == "" {
+		var err error
+		file, err = kubeconfig.Location()
+		if err != nil {
+			return fmt.Errorf("failed to find kubeconfig file: %w", err)
+		}
+	}
+	target, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read kubeconfig '%s': %w", file, err)
+	}
+	merged, context, err := kubeconfig.Merge(target, []byte(source), clusterId, c.switchContext)
+	if err != nil {
+		return err
+	}
+	err = kubeconfig.Write(file, merged)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig '%s': %w", file, err)
+	}
+	fmt.Printf("Kubeconfig of cluster '%s' merged into '%s'\n", clusterId, file)
+	if c.switchContext && context != "" {
+		fmt.Printf("Current context is now '%s'\n", context)
+	}
+	return nil
+}
+
+// writeKubeconfig writes the kubeconfig of a cluster to a file in the output directory. The file is only readable by
+// the current user, as it contains credentials.
+func (c *runnerContext) writeKubeconfig(clusterId, kubeconfig string) (file string, err error) {
+	file = filepath.Join(c.dir, clusterId+".kubeconfig")
+	err = os.WriteFile(file, []byte(kubeconfig), 0600)
+	if err != nil {
+		err = fmt.Errorf("failed to write kubeconfig '%s': %w", file, err)
+	}
+	return
+}
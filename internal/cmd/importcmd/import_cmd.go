@@ -0,0 +1,327 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package importcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/hooks"
+	"github.com/innabox/fulfillment-cli/internal/mutation"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/reflection"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "import [flags] DIRECTORY",
+		Short: "Create the objects exported to a directory",
+		Long: "Create the objects that were exported to a directory with the 'export' command, for example to copy " +
+			"them to another environment. By default only cluster orders are created, use the '--type' flag to " +
+			"also create templates or clusters. Templates are created first, then clusters and then cluster " +
+			"orders, so that orders can reference the templates. The 'status' and 'metadata' fields are ignored, " +
+			"as they are assigned by the server, and so are the identifiers of clusters and cluster orders, so " +
+			"new ones are assigned. The identifiers of templates are kept, as orders reference them. The " +
+			"objects are only created after confirmation, as each cluster and order provisions a new cluster. If " +
+			"an object can't be created the rest are still created.",
+		Example: "" +
+			"  # Export the templates and orders from one environment and create them in another:\n" +
+			"  fulfillment-cli export --type clustertemplates,clusterorders --output backup/\n" +
+			"  fulfillment-cli login --address other.example.com ...\n" +
+			"  fulfillment-cli import backup/ --type clustertemplates,clusterorders",
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringSliceVar(
+		&runner.kinds,
+		"type",
+		[]string{"clusterorders"},
+		"Types of the objects to import, 'clusterorders', 'clusters' or 'clustertemplates'. Several types "+
+			"separated by commas can be given.",
+	)
+	runner.mutation = mutation.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	kinds    []string
+	mutation *mutation.Flags
+	cfg      *config.Config
+	conn     *grpc.ClientConn
+}
+
+// importer describes how to create the objects of one type.
+type importer struct {
+	// kind is the name of the type used in the hooks and in the names of the objects, like 'clusterorder'.
+	kind string
+
+	// dir is the name of the subdirectory that contains the objects, like 'clusterorders'. It is also the name used
+	// in the '--type' flag.
+	dir string
+
+	// clear contains the fields that are removed before creating the objects, because they are assigned by the
+	// server.
+	clear []string
+
+	// object creates an empty object of the type.
+	object func() proto.Message
+
+	// request creates the request that creates the given object.
+	request func(object proto.Message) proto.Message
+
+	// create sends the request and returns the identifier of the created object.
+	create func(ctx context.Context, conn *grpc.ClientConn, request proto.Message) (string, error)
+}
+
+// importers are the types that can be imported, in the order that they are created.
+var importers = []importer{
+	{
+		kind:   "clustertemplate",
+		dir:    "clustertemplates",
+		clear:  []string{"status", "metadata"},
+		object: func() proto.Message { return &fulfillmentv1.ClusterTemplate{} },
+		request: func(object proto.Message) proto.Message {
+			return &fulfillmentv1.ClusterTemplatesCreateRequest{
+				Object: object.(*fulfillmentv1.ClusterTemplate),
+			}
+		},
+		create: func(ctx context.Context, conn *grpc.ClientConn, request proto.Message) (string, error) {
+			response, err := fulfillmentv1.NewClusterTemplatesClient(conn).Create(
+				ctx, request.(*fulfillmentv1.ClusterTemplatesCreateRequest),
+			)
+			return response.GetObject().GetId(), err
+		},
+	},
+	{
+		kind:   "cluster",
+		dir:    "clusters",
+		clear:  []string{"id", "status", "metadata"},
+		object: func() proto.Message { return &fulfillmentv1.Cluster{} },
+		request: func(object proto.Message) proto.Message {
+			return &fulfillmentv1.ClustersCreateRequest{
+				Object: object.(*fulfillmentv1.Cluster),
+			}
+		},
+		create: func(ctx context.Context, conn *grpc.ClientConn, request proto.Message) (string, error) {
+			response, err := fulfillmentv1.NewClustersClient(conn).Create(
+				ctx, request.(*fulfillmentv1.ClustersCreateRequest),
+			)
+			return response.GetObject().GetId(), err
+		},
+	},
+	{
+		kind:   "clusterorder",
+		dir:    "clusterorders",
+		clear:  []string{"id", "status", "metadata"},
+		object: func() proto.Message { return &fulfillmentv1.ClusterOrder{} },
+		request: func(object proto.Message) proto.Message {
+			return &fulfillmentv1.ClusterOrdersCreateRequest{
+				Object: object.(*fulfillmentv1.ClusterOrder),
+			}
+		},
+		create: func(ctx context.Context, conn *grpc.ClientConn, request proto.Message) (string, error) {
+			response, err := fulfillmentv1.NewClusterOrdersClient(conn).Create(
+				ctx, request.(*fulfillmentv1.ClusterOrdersCreateRequest),
+			)
+			return response.GetObject().GetId(), err
+		},
+	},
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the arguments and flags:
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one directory")
+	}
+	err := c.mutation.Validate()
+	if err != nil {
+		return err
+	}
+	selected := map[string]bool{}
+	for _, kind := range c.kinds {
+		dir := normalizeKind(kind)
+		if dir == "" {
+			return fmt.Errorf(
+				"unsupported object type '%s', valid types are 'clusterorders', 'clusters' and 'clustertemplates'",
+				kind,
+			)
+		}
+		selected[dir] = true
+	}
+
+	// Find the files to import, so that problems with the directory are reported before connecting to the server:
+	root := args[0]
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("failed to check directory '%s': %w", root, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("'%s' isn't a directory", root)
+	}
+	files := map[string][]string{}
+	total := 0
+	for _, importer := range importers {
+		if !selected[importer.dir] {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(root, importer.dir, "*.json"))
+		if err != nil {
+			return fmt.Errorf("failed to list files of directory '%s': %w", root, err)
+		}
+		sort.Strings(matches)
+		files[importer.dir] = matches
+		total += len(matches)
+	}
+	if total == 0 {
+		return fmt.Errorf(
+			"directory '%s' doesn't contain objects of the selected types, it should have been created with "+
+				"the 'export' command",
+			root,
+		)
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+	c.cfg = cfg
+
+	// Create the gRPC connection from the configuration:
+	c.conn, err = cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Ask for confirmation, unless explicitly disabled or this is a client dry run:
+	if !c.mutation.Yes && !c.mutation.Client() {
+		var counts []string
+		for _, importer := range importers {
+			if len(files[importer.dir]) > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", len(files[importer.dir]), importer.dir))
+			}
+		}
+		question := fmt.Sprintf(
+			"Create %s in '%s'%s?",
+			strings.Join(counts, ", "), cfg.Address, c.mutation.Suffix(),
+		)
+		confirmed, err := c.mutation.Confirm(question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintf(os.Stderr, "No object was imported\n")
+			return nil
+		}
+	}
+
+	// Create the objects, continuing with the rest if one of them fails:
+	created := 0
+	for _, importer := range importers {
+		for _, file := range files[importer.dir] {
+			err = c.importFile(ctx, importer, file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to import '%s': %v\n", file, err)
+				continue
+			}
+			created++
+		}
+	}
+	fmt.Printf("Imported %d of %d objects%s\n", created, total, c.mutation.Suffix())
+	if created < total {
+		return fmt.Errorf("failed to import %d objects", total-created)
+	}
+
+	return nil
+}
+
+// importFile creates the object contained in the given file, running the hooks that the user may have configured
+// before and after that. In dry runs the hooks aren't executed, and for client dry runs nothing is sent to the server.
+func (c *runnerContext) importFile(ctx context.Context, importer importer, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	object := importer.object()
+	err = protojson.Unmarshal(data, object)
+	if err != nil {
+		return fmt.Errorf("failed to parse object: %w", err)
+	}
+	id := object.(output.Identified).GetId()
+	reflection.Clear(object, importer.clear...)
+	request := importer.request(object)
+	if c.mutation.Client() {
+		c.imported(importer, id)
+		return nil
+	}
+	err = hooks.Pre(ctx, c.cfg, &hooks.Event{
+		Operation: hooks.OperationCreate,
+		Type:      importer.kind,
+		Object:    object,
+	})
+	if err != nil {
+		return err
+	}
+	id, err = importer.create(ctx, c.conn, request)
+	if err != nil {
+		return err
+	}
+	hooks.Post(ctx, c.cfg, &hooks.Event{
+		Operation: hooks.OperationCreate,
+		Type:      importer.kind,
+		ID:        id,
+		Object:    object,
+	})
+	c.imported(importer, id)
+	return nil
+}
+
+// normalizeKind returns the plural name of the given object type, which is also the name of the subdirectory that
+// contains the objects, or an empty string if the type isn't supported.
+func normalizeKind(kind string) string {
+	switch kind {
+	case "cluster", "clusters":
+		return "clusters"
+	case "clusterorder", "clusterorders":
+		return "clusterorders"
+	case "clustertemplate", "clustertemplates":
+		return "clustertemplates"
+	default:
+		return ""
+	}
+}
+
+// imported reports that an object has been created. In client dry runs the identifier is the one of the exported
+// object, as no new one has been assigned.
+func (c *runnerContext) imported(importer importer, id string) {
+	fmt.Printf("Created %s/%s%s\n", importer.kind, id, c.mutation.Suffix())
+}
@@ -14,10 +14,26 @@ language governing permissions and limitations under the License.
 package login
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/auth"
 	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/proxy"
+	"github.com/innabox/fulfillment-cli/internal/secrets"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func Cmd() *cobra.Command {
@@ -52,16 +68,130 @@ func Cmd() *cobra.Command {
 		"",
 		"Server address",
 	)
+	flags.StringVar(
+		&runner.tlsCert,
+		"tls-cert",
+		"",
+		"File containing the client certificate used for mutual TLS authentication. Requires '--tls-key'.",
+	)
+	flags.StringVar(
+		&runner.tlsKey,
+		"tls-key",
+		"",
+		"File containing the key of the client certificate used for mutual TLS authentication",
+	)
+	flags.StringVar(
+		&runner.tlsCA,
+		"tls-ca",
+		"",
+		"File containing the certificates of the authorities trusted to sign the certificate of the server. The "+
+			"default is to use the certificates of the system.",
+	)
+	flags.StringVar(
+		&runner.proxy,
+		"proxy",
+		"",
+		"URL of the HTTP, HTTPS or SOCKS5 proxy used to connect to the server, for example "+
+			"'http://proxy.example.com:3128' or 'socks5://localhost:1080'. The default is to use the proxy of the "+
+			"'HTTPS_PROXY' environment variable, if any. In both cases the 'NO_PROXY' environment variable is "+
+			"honored.",
+	)
+	flags.StringVar(
+		&runner.oidcIssuer,
+		"oidc-issuer",
+		"",
+		"URL of the OpenID Connect provider. When specified the token is obtained using the OAuth2 device "+
+			"authorization grant, instead of using the '--token' flag.",
+	)
+	flags.StringVar(
+		&runner.clientID,
+		"client-id",
+		"",
+		"OAuth2 client identifier used with the OpenID Connect provider",
+	)
+	flags.StringVar(
+		&runner.fromKubeSecret,
+		"from-kube-secret",
+		"",
+		"Read the address and the token from the 'address' and 'token' keys of a Kubernetes secret, given as "+
+			"'namespace/name'. The secret is read with the 'kubectl' command, so it uses the current kubeconfig. "+
+			"Values given with the '--address' and '--token' flags take precedence.",
+	)
+	flags.BoolVar(
+		&runner.fromEnv,
+		"from-env",
+		false,
+		fmt.Sprintf(
+			"Read the address and the token from the '%s' and '%s' environment variables. Values given with "+
+				"the '--address' and '--token' flags take precedence.",
+			addressEnvVar, tokenEnvVar,
+		),
+	)
+	flags.StringVar(
+		&runner.credentialStore,
+		"credential-store",
+		secrets.StoreFile,
+		fmt.Sprintf(
+			"Where to store the tokens, one of %s. With 'keyring' the tokens are kept in the keyring of "+
+				"the operating system instead of the configuration file.",
+			strings.Join(quote(secrets.Stores), ", "),
+		),
+	)
+	flags.DurationVar(
+		&runner.timeout,
+		"timeout",
+		0,
+		"Maximum time that each call to the server can take, for example '30s'. The default is no limit.",
+	)
+	flags.IntVar(
+		&runner.retries,
+		"retries",
+		0,
+		"Number of times that calls that only read data are retried when the server is unavailable",
+	)
+	flags.DurationVar(
+		&runner.retryBackoff,
+		"retry-backoff",
+		time.Second,
+		"Delay before the first retry, doubled after each retry",
+	)
+	flags.BoolVar(
+		&runner.validate,
+		"validate",
+		true,
+		"After saving the configuration connect to the server and send a simple request, so that problems with "+
+			"the address, the TLS settings or the credentials are reported immediately. Use '--validate=false' "+
+			"to save the configuration when the server isn't reachable yet.",
+	)
 	return result
 }
 
 type runnerContext struct {
-	token     string
-	plaintext bool
-	insecure  bool
-	address   string
+	token           string
+	plaintext       bool
+	insecure        bool
+	address         string
+	tlsCert         string
+	tlsKey          string
+	tlsCA           string
+	proxy           string
+	oidcIssuer      string
+	clientID        string
+	fromKubeSecret  string
+	fromEnv         bool
+	credentialStore string
+	timeout         time.Duration
+	retries         int
+	retryBackoff    time.Duration
+	validate        bool
 }
 
+// Names of the environment variables used by the '--from-env' flag:
+const (
+	addressEnvVar = "FULFILLMENT_CLI_ADDRESS"
+	tokenEnvVar   = "FULFILLMENT_CLI_TOKEN"
+)
+
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 	// Load the configuration:
 	cfg, err := config.Load()
@@ -72,16 +202,131 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		cfg = &config.Config{}
 	}
 
+	// Get the address and token from the environment or from a Kubernetes secret, if requested:
+	if c.fromEnv && c.fromKubeSecret != "" {
+		return fmt.Errorf("flags '--from-env' and '--from-kube-secret' can't be used together")
+	}
+	if c.fromEnv {
+		c.merge(os.Getenv(addressEnvVar), os.Getenv(tokenEnvVar))
+	}
+	if c.fromKubeSecret != "" {
+		address, token, err := c.readKubeSecret(cmd.Context())
+		if err != nil {
+			return err
+		}
+		c.merge(address, token)
+	}
+
 	// Check mandatory parameters:
 	if c.address == "" {
 		return fmt.Errorf("address is mandatory")
 	}
+	if c.oidcIssuer != "" {
+		if c.clientID == "" {
+			return fmt.Errorf("client-id is mandatory when using an OpenID Connect provider")
+		}
+		if c.token != "" {
+			return fmt.Errorf("token can't be used together with an OpenID Connect provider")
+		}
+	}
+	if c.timeout < 0 {
+		return fmt.Errorf("timeout can't be negative, but it is %s", c.timeout)
+	}
+	if c.retries < 0 {
+		return fmt.Errorf("retries can't be negative, but it is %d", c.retries)
+	}
+	if c.retryBackoff <= 0 {
+		return fmt.Errorf("retry backoff should be positive, but it is %s", c.retryBackoff)
+	}
+	if (c.tlsCert == "") != (c.tlsKey == "") {
+		return fmt.Errorf("flags '--tls-cert' and '--tls-key' must be used together")
+	}
+	if c.plaintext && (c.tlsCert != "" || c.tlsCA != "") {
+		return fmt.Errorf("flags '--tls-cert' and '--tls-ca' can't be used together with '--plaintext'")
+	}
+	if c.proxy != "" {
+		_, err = proxy.Parse(c.proxy)
+		if err != nil {
+			return err
+		}
+	}
+	if !slices.Contains(secrets.Stores, c.credentialStore) {
+		return fmt.Errorf(
+			"credential store '%s' isn't supported, it should be one of %s",
+			c.credentialStore, strings.Join(quote(secrets.Stores), ", "),
+		)
+	}
+
+	// If the tokens were stored in the keyring and they will no longer be stored there, then remove them:
+	if cfg.CredentialStore == secrets.StoreKeyring && c.credentialStore != secrets.StoreKeyring {
+		cfg.Token = ""
+		cfg.RefreshToken = ""
+		err = config.Save(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to remove tokens from the keyring: %w", err)
+		}
+	}
 
 	// Update the configuration with the values given in the command line:
 	cfg.Token = c.token
 	cfg.Plaintext = c.plaintext
 	cfg.Insecure = c.insecure
 	cfg.Address = c.address
+	cfg.Proxy = c.proxy
+	cfg.TLSCert, err = c.checkFile(c.tlsCert)
+	if err != nil {
+		return err
+	}
+	cfg.TLSKey, err = c.checkFile(c.tlsKey)
+	if err != nil {
+		return err
+	}
+	cfg.TLSCA, err = c.checkFile(c.tlsCA)
+	if err != nil {
+		return err
+	}
+	if cfg.TLSCert != "" {
+		_, err = tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+	}
+	if cfg.TLSCA != "" {
+		_, err = config.LoadCA(cfg.TLSCA)
+		if err != nil {
+			return err
+		}
+	}
+	cfg.RefreshToken = ""
+	cfg.OIDC = nil
+	cfg.CredentialStore = ""
+	if c.credentialStore != secrets.StoreFile {
+		cfg.CredentialStore = c.credentialStore
+	}
+
+	// The timeout and retry settings aren't related to a particular server, so they are only changed when explicitly
+	// requested:
+	flags := cmd.Flags()
+	if flags.Changed("timeout") {
+		cfg.Timeout = ""
+		if c.timeout > 0 {
+			cfg.Timeout = c.timeout.String()
+		}
+	}
+	if flags.Changed("retries") {
+		cfg.Retries = c.retries
+	}
+	if flags.Changed("retry-backoff") {
+		cfg.RetryBackoff = c.retryBackoff.String()
+	}
+
+	// Obtain the token from the OpenID Connect provider, if requested:
+	if c.oidcIssuer != "" {
+		err = c.oidcLogin(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Save the configuration:
 	err = config.Save(cfg)
@@ -89,5 +334,153 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
+	// Check that the server can be used with the saved configuration:
+	if c.validate {
+		err = c.check(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateTimeout is the maximum time that the request sent to validate the configuration can take.
+const validateTimeout = 30 * time.Second
+
+// check connects to the server and lists one cluster template, to verify that the address, the TLS settings and the
+// credentials work. The configuration is already saved, so that the user can fix it with the 'config set' command
+// or by running 'login' again. A server that denies the permission to list templates did accept the credentials, so
+// that isn't considered an error.
+func (c *runnerContext) check(ctx context.Context, cfg *config.Config) error {
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("configuration was saved, but failed to create gRPC connection: %w", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(ctx, validateTimeout)
+	defer cancel()
+	limit := int32(1)
+	_, err = fulfillmentv1.NewClusterTemplatesClient(conn).List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+		Limit: &limit,
+	})
+	switch status.Code(err) {
+	case codes.OK, codes.PermissionDenied:
+		return nil
+	case codes.Unauthenticated:
+		return fmt.Errorf(
+			"configuration was saved, but the server at '%s' rejected the credentials: %w",
+			cfg.Address, err,
+		)
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Errorf(
+			"configuration was saved, but the server at '%s' can't be reached, check the address, the proxy "+
+				"and the TLS settings: %w",
+			cfg.Address, err,
+		)
+	default:
+		return fmt.Errorf("configuration was saved, but the request to validate it failed: %w", err)
+	}
+}
+
+// checkFile checks that the given file exists and returns its absolute path, so that the configuration will work
+// regardless of the directory where the tool is executed. Empty names are returned as they are.
+func (c *runnerContext) checkFile(name string) (result string, err error) {
+	if name == "" {
+		return
+	}
+	result, err = filepath.Abs(name)
+	if err != nil {
+		err = fmt.Errorf("failed to get absolute path of '%s': %w", name, err)
+		return
+	}
+	_, err = os.Stat(result)
+	if err != nil {
+		err = fmt.Errorf("failed to check file '%s': %w", name, err)
+	}
+	return
+}
+
+// merge uses the given address and token when they haven't been explicitly given in the command line.
+func (c *runnerContext) merge(address, token string) {
+	if c.address == "" {
+		c.address = address
+	}
+	if c.token == "" {
+		c.token = token
+	}
+}
+
+// readKubeSecret reads the address and the token from the Kubernetes secret given with the '--from-kube-secret' flag.
+func (c *runnerContext) readKubeSecret(ctx context.Context) (address, token string, err error) {
+	namespace, name, found := strings.Cut(c.fromKubeSecret, "/")
+	if !found || namespace == "" || name == "" {
+		err = fmt.Errorf(
+			"secret '%s' isn't valid, it should have the format 'namespace/name'",
+			c.fromKubeSecret,
+		)
+		return
+	}
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	kubectl := exec.CommandContext(
+		ctx,
+		"kubectl", "get", "secret", name,
+		"--namespace", namespace,
+		"--output", "json",
+	)
+	kubectl.Stdout = stdout
+	kubectl.Stderr = stderr
+	err = kubectl.Run()
+	if err != nil {
+		err = fmt.Errorf(
+			"failed to get secret '%s': %w: %s",
+			c.fromKubeSecret, err, strings.TrimSpace(stderr.String()),
+		)
+		return
+	}
+	var secret struct {
+		Data map[string][]byte `json:"data"`
+	}
+	err = json.Unmarshal(stdout.Bytes(), &secret)
+	if err != nil {
+		err = fmt.Errorf("failed to parse secret '%s': %w", c.fromKubeSecret, err)
+		return
+	}
+	address = strings.TrimSpace(string(secret.Data["address"]))
+	token = strings.TrimSpace(string(secret.Data["token"]))
+	if address == "" && token == "" {
+		err = fmt.Errorf("secret '%s' doesn't contain the 'address' or 'token' keys", c.fromKubeSecret)
+	}
+	return
+}
+
+// oidcLogin obtains the access and refresh tokens using the device authorization grant, and stores them in the
+// configuration together with the details of the provider.
+func (c *runnerContext) oidcLogin(ctx context.Context, cfg *config.Config) error {
+	provider, err := auth.Discover(ctx, c.oidcIssuer)
+	if err != nil {
+		return err
+	}
+	token, err := auth.DeviceLogin(ctx, provider, c.clientID, auth.DefaultScopes, os.Stdout)
+	if err != nil {
+		return err
+	}
+	cfg.Token = token.AccessToken
+	cfg.RefreshToken = token.RefreshToken
+	cfg.OIDC = &config.OIDCConfig{
+		Issuer:             c.oidcIssuer,
+		ClientID:           c.clientID,
+		TokenEndpoint:      provider.TokenEndpoint,
+		RevocationEndpoint: provider.RevocationEndpoint,
+	}
 	return nil
 }
+
+func quote(values []string) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = fmt.Sprintf("'%s'", value)
+	}
+	return result
+}
@@ -14,8 +14,11 @@ language governing permissions and limitations under the License.
 package logout
 
 import (
+	"context"
 	"fmt"
+	"os"
 
+	"github.com/innabox/fulfillment-cli/internal/auth"
 	"github.com/innabox/fulfillment-cli/internal/config"
 	"github.com/spf13/cobra"
 )
@@ -25,15 +28,38 @@ func Cmd() *cobra.Command {
 	result := &cobra.Command{
 		Use:   "logout [flags]",
 		Short: "Discard connection and authentication details",
-		RunE:  runner.run,
+		Long: "Discard connection and authentication details. By default the server address, the tokens and the " +
+			"TLS settings are discarded, and other settings, like telemetry, are preserved. If the tokens were " +
+			"obtained from an OpenID Connect provider that supports revocation, the refresh token is also revoked.",
+		RunE: runner.run,
 	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.tokensOnly,
+		"tokens-only",
+		false,
+		"Discard only the tokens, preserving the server address and the rest of the settings",
+	)
+	flags.BoolVar(
+		&runner.all,
+		"all",
+		false,
+		"Delete the complete configuration, including settings that aren't related to the connection",
+	)
 	return result
 }
 
 type runnerContext struct {
+	tokensOnly bool
+	all        bool
 }
 
 func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the flags:
+	if c.tokensOnly && c.all {
+		return fmt.Errorf("flags '--tokens-only' and '--all' can't be used together")
+	}
+
 	// Load the configuration:
 	cfg, err := config.Load()
 	if err != nil {
@@ -43,11 +69,29 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 		cfg = &config.Config{}
 	}
 
-	// Clear all the details:
+	// Revoke the refresh token, if possible. Failing to do so shouldn't prevent discarding the local copy.
+	c.revoke(cmd.Context(), cfg)
+
+	// Delete everything if requested:
+	if c.all {
+		err = config.Delete(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to delete configuration: %w", err)
+		}
+		return nil
+	}
+
+	// Clear the tokens:
 	cfg.Token = ""
-	cfg.Plaintext = false
-	cfg.Insecure = false
-	cfg.Address = ""
+	cfg.RefreshToken = ""
+
+	// Clear the rest of the connection details, unless only tokens should be discarded:
+	if !c.tokensOnly {
+		cfg.Plaintext = false
+		cfg.Insecure = false
+		cfg.Address = ""
+		cfg.OIDC = nil
+	}
 
 	// Save the configuration:
 	err = config.Save(cfg)
@@ -57,3 +101,14 @@ func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// revoke asks the OpenID Connect provider to revoke the refresh token, if there is one and the provider supports it.
+func (c *runnerContext) revoke(ctx context.Context, cfg *config.Config) {
+	if cfg.RefreshToken == "" || cfg.OIDC == nil || cfg.OIDC.RevocationEndpoint == "" {
+		return
+	}
+	err := auth.Revoke(ctx, cfg.OIDC.RevocationEndpoint, cfg.OIDC.ClientID, cfg.RefreshToken)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/recording"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "session [flags]",
+		Short: "Start recording the commands of a session",
+		Long: "Start recording the commands of a session. The commands that complete successfully after this will " +
+			"be recorded, and when the 'record stop' command is executed they will be written to the output file " +
+			"as a shell script. The values of secret flags, like the authentication token, and of secret " +
+			"settings given to the 'config set' command, like the proxy, will be replaced by references to " +
+			"environment variables.",
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.output,
+		"output",
+		"",
+		"Location of the generated shell script",
+	)
+	return result
+}
+
+type runnerContext struct {
+	output string
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check mandatory parameters:
+	if c.output == "" {
+		return fmt.Errorf("output is mandatory")
+	}
+
+	// Check that there is no session already being recorded:
+	session, err := recording.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session != nil {
+		return fmt.Errorf(
+			"there is already a session being recorded to '%s', run the 'record stop' command first",
+			session.Output,
+		)
+	}
+
+	// Start the new session:
+	output, err := filepath.Abs(c.output)
+	if err != nil {
+		return fmt.Errorf("failed to calculate absolute path of '%s': %w", c.output, err)
+	}
+	session = &recording.Session{
+		Output:  output,
+		Started: time.Now(),
+	}
+	err = recording.Save(session)
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	fmt.Printf("Recording session to '%s', run the 'record stop' command to finish\n", output)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package stop
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/recording"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "stop [flags]",
+		Short: "Stop recording and write the shell script",
+		RunE:  runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Load the session:
+	session, err := recording.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return fmt.Errorf("there is no session being recorded, run the 'record session' command")
+	}
+
+	// Write the script:
+	script := session.Script(cmd.Root().Name())
+	err = os.WriteFile(session.Output, []byte(script), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to write script '%s': %w", session.Output, err)
+	}
+
+	// Discard the session:
+	err = recording.Delete()
+	if err != nil {
+		return fmt.Errorf("failed to discard session: %w", err)
+	}
+	fmt.Printf("Wrote %d commands to '%s'\n", len(session.Commands), session.Output)
+
+	return nil
+}
@@ -14,30 +14,300 @@ language governing permissions and limitations under the License.
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/innabox/fulfillment-cli/internal/cmd/bench"
+	"github.com/innabox/fulfillment-cli/internal/cmd/cache"
+	"github.com/innabox/fulfillment-cli/internal/cmd/changes"
+	"github.com/innabox/fulfillment-cli/internal/cmd/compat"
+	configcmd "github.com/innabox/fulfillment-cli/internal/cmd/config"
+	"github.com/innabox/fulfillment-cli/internal/cmd/count"
 	"github.com/innabox/fulfillment-cli/internal/cmd/create"
 	"github.com/innabox/fulfillment-cli/internal/cmd/delete"
 	"github.com/innabox/fulfillment-cli/internal/cmd/describe"
+	"github.com/innabox/fulfillment-cli/internal/cmd/edit"
+	"github.com/innabox/fulfillment-cli/internal/cmd/events"
+	"github.com/innabox/fulfillment-cli/internal/cmd/export"
 	"github.com/innabox/fulfillment-cli/internal/cmd/get"
 	"github.com/innabox/fulfillment-cli/internal/cmd/getkubeconfig"
+	"github.com/innabox/fulfillment-cli/internal/cmd/importcmd"
 	"github.com/innabox/fulfillment-cli/internal/cmd/login"
 	"github.com/innabox/fulfillment-cli/internal/cmd/logout"
+	"github.com/innabox/fulfillment-cli/internal/cmd/record"
+	"github.com/innabox/fulfillment-cli/internal/cmd/sos"
+	"github.com/innabox/fulfillment-cli/internal/cmd/telemetry"
+	"github.com/innabox/fulfillment-cli/internal/cmd/trash"
+	"github.com/innabox/fulfillment-cli/internal/cmd/version"
+	"github.com/innabox/fulfillment-cli/internal/cmd/whoami"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/experimental"
+	"github.com/innabox/fulfillment-cli/internal/interceptors"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paths"
+	"github.com/innabox/fulfillment-cli/internal/recording"
+	"github.com/innabox/fulfillment-cli/internal/terminal"
 )
 
 func Root() *cobra.Command {
 	result := &cobra.Command{
-		Use:           "fulfillment-cli",
-		Short:         "Command line interface for the fulfillment API",
-		SilenceUsage:  true,
-		SilenceErrors: true,
+		Use:                "fulfillment-cli",
+		Short:              "Command line interface for the fulfillment API",
+		Long:               "Command line interface for the fulfillment API.\n\n" + exitCodesHelp,
+		SilenceUsage:       true,
+		SilenceErrors:      true,
+		PersistentPreRunE:  preRun,
+		PersistentPostRunE: postRun,
 	}
+	flags := result.PersistentFlags()
+	flags.String(
+		errorFormatFlag,
+		errorFormatText,
+		fmt.Sprintf("Format of error messages, '%s' or '%s'", errorFormatText, errorFormatJSON),
+	)
+	flags.String(
+		timestampsFlag,
+		output.TimestampsLocal,
+		fmt.Sprintf(
+			"Format of timestamps, one of '%s'",
+			strings.Join(output.TimestampFormats, "', '"),
+		),
+	)
+	result.RegisterFlagCompletionFunc(
+		timestampsFlag,
+		cobra.FixedCompletions(output.TimestampFormats, cobra.ShellCompDirectiveNoFileComp),
+	)
+	flags.Bool(
+		noDefaultFiltersFlag,
+		false,
+		"Don't add the filters from the 'default_filters' section of the configuration file to the requests "+
+			"that list objects",
+	)
+	flags.Bool(
+		quietFlag,
+		false,
+		"Don't display decorative output, like progress spinners and informational messages",
+	)
+	flags.String(
+		debugGRPCFlag,
+		"",
+		fmt.Sprintf(
+			"Write to the standard error a line for each gRPC call, with the method, sizes, latency and status "+
+				"code. With '--%s=%s' the metadata and the payloads are also written, with the authorization "+
+				"metadata redacted. Note that the payloads may contain other sensitive data, like kubeconfigs.",
+			debugGRPCFlag, interceptors.DebugFull,
+		),
+	)
+	flags.Lookup(debugGRPCFlag).NoOptDefVal = interceptors.DebugBasic
+	result.RegisterFlagCompletionFunc(
+		debugGRPCFlag,
+		cobra.FixedCompletions(interceptors.DebugLevels, cobra.ShellCompDirectiveNoFileComp),
+	)
+	flags.String(
+		recordGRPCFlag,
+		"",
+		"Write each gRPC call, with its request and its response, to a JSON file in the given directory, so that "+
+			"it can later be replayed with '--"+replayGRPCFlag+"'. Note that the responses may contain sensitive "+
+			"data, like kubeconfigs.",
+	)
+	flags.String(
+		replayGRPCFlag,
+		"",
+		"Answer the gRPC calls with the ones recorded in the given directory with '--"+recordGRPCFlag+"', "+
+			"without contacting the server. Calls that weren't recorded fail.",
+	)
+	result.MarkFlagsMutuallyExclusive(recordGRPCFlag, replayGRPCFlag)
+	flags.String(
+		outputFileFlag,
+		"-",
+		"File where the output of the command is written, or '-' for the standard output. The file is replaced "+
+			"only when the command succeeds, and it is only readable by the user when the output may contain "+
+			"sensitive data, like kubeconfigs.",
+	)
+	result.MarkPersistentFlagFilename(outputFileFlag)
+	result.MarkPersistentFlagDirname(recordGRPCFlag)
+	result.MarkPersistentFlagDirname(replayGRPCFlag)
+	result.AddCommand(bench.Cmd())
+	result.AddCommand(cache.Cmd())
+	result.AddCommand(changes.Cmd())
+	result.AddCommand(compat.Cmd())
+	result.AddCommand(configcmd.Cmd())
+	result.AddCommand(count.Cmd())
 	result.AddCommand(create.Cmd())
 	result.AddCommand(delete.Cmd())
 	result.AddCommand(describe.Cmd())
+	result.AddCommand(edit.Cmd())
+	result.AddCommand(events.Cmd())
+	result.AddCommand(export.Cmd())
 	result.AddCommand(get.Cmd())
 	result.AddCommand(getkubeconfig.Cmd())
+	result.AddCommand(importcmd.Cmd())
 	result.AddCommand(login.Cmd())
 	result.AddCommand(logout.Cmd())
+	result.AddCommand(record.Cmd())
+	result.AddCommand(sos.Cmd())
+	result.AddCommand(telemetry.Cmd())
+	result.AddCommand(trash.Cmd())
+	result.AddCommand(version.Cmd())
+	result.AddCommand(whoami.Cmd())
+	experimental.Hide(result)
 	return result
 }
+
+// timestampsFlag is the name of the flag that selects the format of timestamps.
+const timestampsFlag = "timestamps"
+
+// noDefaultFiltersFlag is the name of the flag that disables the default filters of the configuration file.
+const noDefaultFiltersFlag = "no-default-filters"
+
+// debugGRPCFlag is the name of the flag that enables the debug log of the gRPC calls.
+const debugGRPCFlag = "debug-grpc"
+
+// recordGRPCFlag is the name of the flag that enables the recording of the gRPC calls.
+const recordGRPCFlag = "record-grpc"
+
+// replayGRPCFlag is the name of the flag that replays the recorded gRPC calls instead of contacting the server.
+const replayGRPCFlag = "replay-grpc"
+
+// outputFileFlag is the name of the flag that redirects the output of the command to a file.
+const outputFileFlag = "output-file"
+
+// quietFlag is the name of the flag that suppresses decorative output.
+const quietFlag = "quiet"
+
+// preRun is executed before every command.
+func preRun(cmd *cobra.Command, args []string) error {
+	// Check the format of errors:
+	format, err := cmd.Flags().GetString(errorFormatFlag)
+	if err != nil {
+		return err
+	}
+	if format != errorFormatText && format != errorFormatJSON {
+		return fmt.Errorf(
+			"error format '%s' isn't supported, it should be '%s' or '%s'",
+			format, errorFormatText, errorFormatJSON,
+		)
+	}
+
+	// Set the format of timestamps:
+	timestamps, err := cmd.Flags().GetString(timestampsFlag)
+	if err != nil {
+		return err
+	}
+	err = output.SetTimestampFormat(timestamps)
+	if err != nil {
+		return err
+	}
+
+	// Disable the default filters if requested:
+	noDefaultFilters, err := cmd.Flags().GetBool(noDefaultFiltersFlag)
+	if err != nil {
+		return err
+	}
+	if noDefaultFilters {
+		config.DisableDefaultFilters()
+	}
+
+	// Enable the debug log of the gRPC calls if requested:
+	debugGRPC, err := cmd.Flags().GetString(debugGRPCFlag)
+	if err != nil {
+		return err
+	}
+	if debugGRPC != "" && !slices.Contains(interceptors.DebugLevels, debugGRPC) {
+		return fmt.Errorf(
+			"debug level '%s' isn't supported, it should be one of '%s'",
+			debugGRPC, strings.Join(interceptors.DebugLevels, "', '"),
+		)
+	}
+	config.SetDebugGRPC(debugGRPC)
+
+	// Record or replay the gRPC calls if requested:
+	recordGRPC, err := cmd.Flags().GetString(recordGRPCFlag)
+	if err != nil {
+		return err
+	}
+	config.SetRecordGRPC(recordGRPC)
+	replayGRPC, err := cmd.Flags().GetString(replayGRPCFlag)
+	if err != nil {
+		return err
+	}
+	config.SetReplayGRPC(replayGRPC)
+
+	// Redirect the output to a file if requested. The file is renamed or discarded by the FinishStdout function
+	// when the command finishes.
+	outputFile, err := cmd.Flags().GetString(outputFileFlag)
+	if err != nil {
+		return err
+	}
+	if outputFile != "-" && !IsCompletionRequest(cmd) {
+		err = output.RedirectStdout(outputFile, output.FileMode(cmd.Annotations))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Suppress decorative output if requested:
+	quiet, err := cmd.Flags().GetBool(quietFlag)
+	if err != nil {
+		return err
+	}
+	terminal.SetQuiet(quiet)
+
+	// Move files created by older versions of the tool to their current locations:
+	err = paths.Migrate()
+	if err != nil {
+		return fmt.Errorf("failed to migrate files: %w", err)
+	}
+
+	// Reject experimental commands and flags unless the user opted in:
+	err = experimental.Check(cmd, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// postRun is executed after every command that completes successfully.
+func postRun(cmd *cobra.Command, args []string) error {
+	// Requests generated by the shell to complete the command line aren't commands typed by the user:
+	if IsCompletionRequest(cmd) {
+		return nil
+	}
+
+	// Add the command to the session that is being recorded, if any. The command line is recorded after expanding
+	// the aliases, so that the generated script doesn't depend on the configuration of the user that runs it. Note
+	// that the commands that manage the recording itself aren't recorded.
+	session, err := recording.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil {
+		return nil
+	}
+	for parent := cmd; parent != nil; parent = parent.Parent() {
+		if parent.Name() == "record" && parent.Parent() == cmd.Root() {
+			return nil
+		}
+	}
+	line := expandedArgs
+	if line == nil {
+		line = os.Args[1:]
+	}
+	session.Append(line)
+	err = recording.Save(session)
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// IsCompletionRequest checks if the command is one of the hidden commands that the shell uses to complete the
+// command line.
+func IsCompletionRequest(cmd *cobra.Command) bool {
+	return cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd
+}
@@ -0,0 +1,385 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package sos
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/innabox/fulfillment-cli/internal/anonymize"
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/telemetry"
+	"github.com/innabox/fulfillment-cli/internal/terminal"
+	"github.com/innabox/fulfillment-cli/internal/version"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:     "sos [flags]",
+		Aliases: []string{"must-gather"},
+		Short:   "Collect diagnostic information",
+		Long: "Collect diagnostic information into a compressed archive that can be attached to support tickets: " +
+			"the version of the tool, the configuration without the tokens, the usage metrics if they are " +
+			"enabled, the results of connectivity checks and, optionally, copies of the objects given with the " +
+			"'--object' flag. Use '--anonymize' to replace identifiers, URLs and names in those objects.",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.output,
+		"output",
+		"",
+		"File where the archive will be written. The default is 'fulfillment-cli-sos-<timestamp>.tar.gz' in the "+
+			"current directory.",
+	)
+	flags.StringArrayVar(
+		&runner.objects,
+		"object",
+		nil,
+		"Object to add to the archive, with the format 'TYPE/ID', for example 'clusterorder/123'. Can be used "+
+			"multiple times.",
+	)
+	runner.anonymize = anonymize.AddFlags(flags)
+	return result
+}
+
+type runnerContext struct {
+	output    string
+	objects   []string
+	anonymize *anonymize.Flags
+	archive   *tar.Writer
+	now       time.Time
+}
+
+// checkTimeout is the maximum time that each connectivity check can take.
+const checkTimeout = 10 * time.Second
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check the object references before doing anything else:
+	for _, object := range c.objects {
+		kind, id, found := strings.Cut(object, "/")
+		if !found || kind == "" || id == "" {
+			return fmt.Errorf("value '%s' of flag '--object' isn't valid, it should have the format 'TYPE/ID'", object)
+		}
+	}
+
+	// Replace the sensitive values with pseudonyms if requested:
+	err := c.anonymize.Apply()
+	if err != nil {
+		return err
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration. Note that a missing configuration isn't an error here, as that may be exactly the
+	// problem that the user is trying to diagnose.
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Create the archive:
+	c.now = time.Now()
+	if c.output == "" {
+		c.output = fmt.Sprintf("fulfillment-cli-sos-%s.tar.gz", c.now.Format("20060102-150405"))
+	}
+	file, err := os.Create(c.output)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer file.Close()
+	compressor := gzip.NewWriter(file)
+	c.archive = tar.NewWriter(compressor)
+
+	// Add the files, showing the progress as some of them require calls to the server that may be slow:
+	spinner := terminal.StartSpinner(os.Stderr, "Collecting configuration")
+	defer spinner.Stop()
+	err = c.add("version.txt", c.versionInfo())
+	if err != nil {
+		return err
+	}
+	data, err := c.configInfo(cfg)
+	if err != nil {
+		return err
+	}
+	err = c.add("config.json", data)
+	if err != nil {
+		return err
+	}
+	err = c.addTelemetry()
+	if err != nil {
+		return err
+	}
+	var conn *grpc.ClientConn
+	if cfg.Address != "" {
+		conn, err = cfg.Connect()
+		if err != nil {
+			return fmt.Errorf("failed to create gRPC connection: %w", err)
+		}
+		defer conn.Close()
+	}
+	spinner.Update("Checking connectivity")
+	err = c.add("connectivity.txt", c.connectivityInfo(ctx, cfg, conn))
+	if err != nil {
+		return err
+	}
+	for i, object := range c.objects {
+		kind, id, _ := strings.Cut(object, "/")
+		spinner.Updatef("Collecting %s", object)
+		err = c.addObject(ctx, conn, i+1, kind, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	spinner.Stop()
+
+	// Close the archive:
+	err = c.archive.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	err = compressor.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	fmt.Printf("Diagnostic information written to '%s'\n", c.output)
+
+	return nil
+}
+
+// add adds a file to the archive.
+func (c *runnerContext) add(name string, data []byte) error {
+	err := c.archive.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: c.now,
+	})
+	if err == nil {
+		_, err = c.archive.Write(data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add '%s' to archive: %w", name, err)
+	}
+	return nil
+}
+
+// versionInfo returns the version of the tool and the details of the platform.
+func (c *runnerContext) versionInfo() []byte {
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "Client version: %s\n", version.Version)
+	fmt.Fprintf(buffer, "Commit: %s\n", version.Commit)
+	fmt.Fprintf(buffer, "Build date: %s\n", version.Date)
+	fmt.Fprintf(buffer, "Go version: %s\n", runtime.Version())
+	fmt.Fprintf(buffer, "Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(buffer, "Collected: %s\n", c.now.UTC().Format(time.RFC3339))
+	return buffer.Bytes()
+}
+
+// configInfo returns the configuration with the tokens redacted.
+func (c *runnerContext) configInfo(cfg *config.Config) (result []byte, err error) {
+	result, err = json.MarshalIndent(cfg.Redacted(), "", "  ")
+	if err != nil {
+		err = fmt.Errorf("failed to marshal configuration: %w", err)
+		return
+	}
+	result = append(result, '\n')
+	return
+}
+
+// addTelemetry adds the usage metrics, if the file exists. They contain only the names of the commands, the classes
+// of the errors and the latencies, so they are safe to share, and they help to see what failed recently.
+func (c *runnerContext) addTelemetry() error {
+	file, err := telemetry.Location()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read telemetry file '%s': %w", file, err)
+	}
+	return c.add("telemetry.json", data)
+}
+
+// connectivityInfo checks that the server address can be resolved and that it accepts connections and requests, and
+// returns a report of the results. Failures are included in the report, not returned, as they are what the user
+// wants to know.
+func (c *runnerContext) connectivityInfo(ctx context.Context, cfg *config.Config, conn *grpc.ClientConn) []byte {
+	buffer := &bytes.Buffer{}
+	if cfg.Address == "" {
+		fmt.Fprintf(buffer, "There is no configuration, the 'login' command wasn't used\n")
+		return buffer.Bytes()
+	}
+	fmt.Fprintf(buffer, "Address: %s\n", cfg.Address)
+	fmt.Fprintf(buffer, "Plaintext: %t\n", cfg.Plaintext)
+	fmt.Fprintf(buffer, "Insecure: %t\n", cfg.Insecure)
+
+	// Resolve the host name:
+	host, port, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		host = cfg.Address
+		port = "443"
+	}
+	start := time.Now()
+	resolveCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	addresses, err := net.DefaultResolver.LookupHost(resolveCtx, host)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(buffer, "DNS: failed after %s: %v\n", since(start), err)
+	} else {
+		fmt.Fprintf(buffer, "DNS: %s resolved to %s in %s\n", host, strings.Join(addresses, ", "), since(start))
+	}
+
+	// Open a TCP connection:
+	start = time.Now()
+	dialer := &net.Dialer{
+		Timeout: checkTimeout,
+	}
+	tcp, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		fmt.Fprintf(buffer, "TCP: failed after %s: %v\n", since(start), err)
+	} else {
+		fmt.Fprintf(buffer, "TCP: connected to %s in %s\n", tcp.RemoteAddr(), since(start))
+		tcp.Close()
+	}
+
+	// Send a request to each service, asking for no items, so that only the connection and the permissions are
+	// checked:
+	check := func(name string, call func(context.Context, ...grpc.CallOption) error) {
+		var header metadata.MD
+		start := time.Now()
+		callCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+		err := call(callCtx, grpc.Header(&header))
+		if err != nil {
+			fmt.Fprintf(
+				buffer, "%s: failed after %s with code '%s': %s\n",
+				name, since(start), status.Code(err), status.Convert(err).Message(),
+			)
+			return
+		}
+		fmt.Fprintf(buffer, "%s: succeeded in %s", name, since(start))
+		versions := header.Get("server-version")
+		if len(versions) > 0 {
+			fmt.Fprintf(buffer, ", server version is %s", versions[0])
+		}
+		fmt.Fprintf(buffer, "\n")
+	}
+	var limit int32
+	check("Clusters", func(ctx context.Context, opts ...grpc.CallOption) error {
+		_, err := fulfillmentv1.NewClustersClient(conn).List(ctx, &fulfillmentv1.ClustersListRequest{
+			Limit: &limit,
+		}, opts...)
+		return err
+	})
+	check("Cluster orders", func(ctx context.Context, opts ...grpc.CallOption) error {
+		_, err := fulfillmentv1.NewClusterOrdersClient(conn).List(ctx, &fulfillmentv1.ClusterOrdersListRequest{
+			Limit: &limit,
+		}, opts...)
+		return err
+	})
+	check("Cluster templates", func(ctx context.Context, opts ...grpc.CallOption) error {
+		_, err := fulfillmentv1.NewClusterTemplatesClient(conn).List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+			Limit: &limit,
+		}, opts...)
+		return err
+	})
+
+	return buffer.Bytes()
+}
+
+// addObject retrieves an object and adds it to the archive. If it can't be retrieved the error is added instead, as
+// that may also be useful for the diagnostic. The index is the position of the object in the list given with the
+// '--object' flag, and it is used instead of the identifier in the name of the file when anonymizing.
+func (c *runnerContext) addObject(ctx context.Context, conn *grpc.ClientConn, index int, kind, id string) error {
+	name := fmt.Sprintf("objects/%s-%s", kind, id)
+	if c.anonymize.Enabled {
+		name = fmt.Sprintf("objects/%s-%d", kind, index)
+	}
+	if conn == nil {
+		return c.add(name+".error.txt", []byte("There is no configuration, the 'login' command wasn't used\n"))
+	}
+	var object proto.Message
+	var err error
+	switch kind {
+	case "cluster", "clusters":
+		var response *fulfillmentv1.ClustersGetResponse
+		response, err = fulfillmentv1.NewClustersClient(conn).Get(ctx, &fulfillmentv1.ClustersGetRequest{
+			Id: id,
+		})
+		object = response.GetObject()
+	case "clusterorder", "clusterorders":
+		var response *fulfillmentv1.ClusterOrdersGetResponse
+		response, err = fulfillmentv1.NewClusterOrdersClient(conn).Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+			Id: id,
+		})
+		object = response.GetObject()
+	case "clustertemplate", "clustertemplates":
+		var response *fulfillmentv1.ClusterTemplatesGetResponse
+		response, err = fulfillmentv1.NewClusterTemplatesClient(conn).Get(
+			ctx,
+			&fulfillmentv1.ClusterTemplatesGetRequest{
+				Id: id,
+			},
+		)
+		object = response.GetObject()
+	default:
+		return fmt.Errorf(
+			"unsupported object type '%s', valid types are 'cluster', 'clusterorder' and 'clustertemplate'",
+			kind,
+		)
+	}
+	if err != nil {
+		return c.add(name+".error.txt", []byte(fmt.Sprintf("%v\n", err)))
+	}
+	buffer := &bytes.Buffer{}
+	err = output.JSON(buffer, object)
+	if err != nil {
+		return err
+	}
+	return c.add(name+".json", buffer.Bytes())
+}
+
+// since returns the time elapsed since the given start, rounded to milliseconds.
+func since(start time.Time) time.Duration {
+	return time.Since(start).Round(time.Millisecond)
+}
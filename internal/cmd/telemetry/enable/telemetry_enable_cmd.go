@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package enable
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "enable [flags]",
+		Short: "Enable collection of anonymous usage metrics",
+		RunE:  runner.run,
+	}
+	flags := result.Flags()
+	flags.StringVar(
+		&runner.endpoint,
+		"endpoint",
+		"",
+		"URL where the metrics will be submitted. If not specified the metrics are collected but not submitted.",
+	)
+	return result
+}
+
+type runnerContext struct {
+	endpoint string
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check that the endpoint is a valid URL:
+	if c.endpoint != "" {
+		parsed, err := url.Parse(c.endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return fmt.Errorf("endpoint '%s' isn't a valid HTTP or HTTPS URL", c.endpoint)
+		}
+	}
+
+	// Load the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	// Update and save the configuration:
+	cfg.Telemetry = &config.TelemetryConfig{
+		Enabled:  true,
+		Endpoint: c.endpoint,
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	fmt.Printf("Telemetry enabled, thanks for helping to improve the tool\n")
+
+	return nil
+}
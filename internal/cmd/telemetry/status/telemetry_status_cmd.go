@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/telemetry"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "status [flags]",
+		Short: "Show the telemetry settings and the metrics pending submission",
+		RunE:  runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Load the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Telemetry == nil || !cfg.Telemetry.Enabled {
+		fmt.Printf("Telemetry is disabled\n")
+		return nil
+	}
+
+	// Display the settings:
+	endpoint := cfg.Telemetry.Endpoint
+	if endpoint == "" {
+		endpoint = "-"
+	}
+	fmt.Printf("Telemetry is enabled\n")
+	fmt.Printf("Endpoint: %s\n", endpoint)
+
+	// Display the metrics that haven't been submitted yet:
+	data, err := telemetry.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load collected metrics: %w", err)
+	}
+	report := data.Report()
+	if len(report.Commands) == 0 {
+		fmt.Printf("There are no metrics pending submission\n")
+		return nil
+	}
+	fmt.Printf("Metrics collected since %s:\n", report.Since.Format(time.RFC3339))
+	fmt.Printf("\n")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "COMMAND\tCOUNT\tERRORS\tP50\tP90\tP99\n")
+	for _, command := range report.Commands {
+		errors := 0
+		for _, count := range command.Errors {
+			errors += count
+		}
+		fmt.Fprintf(
+			writer,
+			"%s\t%d\t%d\t%dms\t%dms\t%dms\n",
+			command.Command,
+			command.Count,
+			errors,
+			command.LatencyMs.P50,
+			command.LatencyMs.P90,
+			command.LatencyMs.P99,
+		)
+	}
+	writer.Flush()
+
+	return nil
+}
@@ -0,0 +1,37 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/cmd/telemetry/disable"
+	"github.com/innabox/fulfillment-cli/internal/cmd/telemetry/enable"
+	"github.com/innabox/fulfillment-cli/internal/cmd/telemetry/status"
+)
+
+func Cmd() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage collection of anonymous usage metrics",
+		Long: "Manage collection of anonymous usage metrics. Collection is disabled unless explicitly enabled. " +
+			"When enabled the tool counts how many times each command is executed, the classes of the errors " +
+			"that they return and their latency, and periodically submits that to the configured endpoint. " +
+			"Arguments, flags, addresses, identifiers and tokens are never collected.",
+	}
+	result.AddCommand(disable.Cmd())
+	result.AddCommand(enable.Cmd())
+	result.AddCommand(status.Cmd())
+	return result
+}
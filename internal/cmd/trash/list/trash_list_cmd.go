@@ -0,0 +1,193 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paging"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "list [flags] [TYPE...]",
+		Short: "List deleted objects",
+		Long: "List the objects that have a deletion timestamp. By default all the types are listed, use the " +
+			"arguments to select some of them: 'cluster', 'clusterorder' or 'clustertemplate'. Note that this " +
+			"only shows the deleted objects that the server still returns in the results of the list requests.",
+		RunE:      runner.run,
+		ValidArgs: []string{"cluster", "clusterorder", "clustertemplate"},
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+// entry contains the details of a deleted object.
+type entry struct {
+	kind    string
+	id      string
+	deleted *timestamppb.Timestamp
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Use all the types if none was given:
+	kinds := args
+	if len(kinds) == 0 {
+		kinds = []string{"cluster", "clusterorder", "clustertemplate"}
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Find the deleted objects:
+	var entries []entry
+	for _, kind := range kinds {
+		var found []entry
+		found, err = c.list(ctx, conn, kind)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, found...)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("There are no deleted objects\n")
+		return nil
+	}
+
+	// Display the objects:
+	table := output.NewTable(
+		os.Stdout,
+		output.Column{Header: "TYPE"},
+		output.Column{Header: "ID"},
+		output.Column{Header: "DELETED"},
+	)
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		rows[i] = []string{entry.kind, entry.id, output.Timestamp(entry.deleted)}
+	}
+	table.Write(rows)
+
+	return nil
+}
+
+// list retrieves the objects of the given type that have a deletion timestamp.
+func (c *runnerContext) list(ctx context.Context, conn *grpc.ClientConn, kind string) (result []entry, err error) {
+	add := func(kind, id string, deleted *timestamppb.Timestamp) {
+		if deleted != nil {
+			result = append(result, entry{
+				kind:    kind,
+				id:      id,
+				deleted: deleted,
+			})
+		}
+	}
+	switch kind {
+	case "cluster", "clusters":
+		client := fulfillmentv1.NewClustersClient(conn)
+		var objects []*fulfillmentv1.Cluster
+		objects, err = paging.ListAll(ctx, func(ctx context.Context, offset, limit int32) ([]*fulfillmentv1.Cluster,
+			int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClustersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to list clusters: %w", err)
+			return
+		}
+		for _, object := range objects {
+			add("cluster", object.Id, object.GetMetadata().GetDeletionTimestamp())
+		}
+	case "clusterorder", "clusterorders":
+		client := fulfillmentv1.NewClusterOrdersClient(conn)
+		var objects []*fulfillmentv1.ClusterOrder
+		objects, err = paging.ListAll(ctx, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.ClusterOrder, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterOrdersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to list orders: %w", err)
+			return
+		}
+		for _, object := range objects {
+			add("clusterorder", object.Id, object.GetMetadata().GetDeletionTimestamp())
+		}
+	case "clustertemplate", "clustertemplates":
+		client := fulfillmentv1.NewClusterTemplatesClient(conn)
+		var objects []*fulfillmentv1.ClusterTemplate
+		objects, err = paging.ListAll(ctx, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.ClusterTemplate, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to list templates: %w", err)
+			return
+		}
+		for _, object := range objects {
+			add("clustertemplate", object.Id, object.GetMetadata().GetDeletionTimestamp())
+		}
+	default:
+		err = fmt.Errorf(
+			"unsupported object type '%s', valid types are 'cluster', 'clusterorder' and 'clustertemplate'",
+			kind,
+		)
+	}
+	return
+}
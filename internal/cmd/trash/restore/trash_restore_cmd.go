@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package restore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	sharedv1 "github.com/innabox/fulfillment-cli/internal/api/shared/v1"
+	"github.com/innabox/fulfillment-cli/internal/completion"
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "restore [flags] TYPE ID",
+		Short: "Restore a deleted object",
+		Long: "Restore a deleted object, clearing its deletion timestamp. This only works while the server still " +
+			"keeps the object, and if the server allows clearing the deletion timestamp with an update. The " +
+			"supported types are 'cluster', 'clusterorder' and 'clustertemplate'.",
+		RunE:              runner.run,
+		ValidArgsFunction: runner.complete,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+// deletionMask is the update mask used to clear the deletion timestamp.
+var deletionMask = &fieldmaskpb.FieldMask{
+	Paths: []string{"metadata.deletion_timestamp"},
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Check that there is exactly one object type and one identifier:
+	if len(args) != 2 {
+		return fmt.Errorf("expected exactly one object type and one identifier")
+	}
+	kind := args[0]
+	id := args[1]
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Restore the object:
+	err = c.restore(ctx, conn, kind, id)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored %s '%s'\n", kind, id)
+
+	return nil
+}
+
+// complete suggests the object types for the first argument, and the identifiers of the objects of that type for the
+// second.
+func (c *runnerContext) complete(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return []string{"cluster", "clusterorder", "clustertemplate"}, cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		switch args[0] {
+		case "cluster", "clusters":
+			return completion.Clusters(cmd, args, toComplete)
+		case "clusterorder", "clusterorders":
+			return completion.ClusterOrders(cmd, args, toComplete)
+		case "clustertemplate", "clustertemplates":
+			return completion.ClusterTemplates(cmd, args, toComplete)
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// restore checks that the object is deleted, and then sends the update that clears the deletion timestamp.
+func (c *runnerContext) restore(ctx context.Context, conn *grpc.ClientConn, kind, id string) error {
+	switch kind {
+	case "cluster", "clusters":
+		client := fulfillmentv1.NewClustersClient(conn)
+		response, err := client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
+			Id: id,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get cluster: %w", err)
+		}
+		err = checkDeleted(kind, id, response.Object.GetMetadata())
+		if err != nil {
+			return err
+		}
+		// Updates of clusters don't support masks, so the complete object needs to be sent:
+		object := response.Object
+		object.Metadata.DeletionTimestamp = nil
+		_, err = client.Update(ctx, &fulfillmentv1.ClustersUpdateRequest{
+			Object: object,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore cluster, the server may not allow it: %w", err)
+		}
+	case "clusterorder", "clusterorders":
+		client := fulfillmentv1.NewClusterOrdersClient(conn)
+		response, err := client.Get(ctx, &fulfillmentv1.ClusterOrdersGetRequest{
+			Id: id,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		err = checkDeleted(kind, id, response.Object.GetMetadata())
+		if err != nil {
+			return err
+		}
+		_, err = client.Update(ctx, &fulfillmentv1.ClusterOrdersUpdateRequest{
+			Object: &fulfillmentv1.ClusterOrder{
+				Id:       id,
+				Metadata: &sharedv1.Metadata{},
+			},
+			UpdateMask: deletionMask,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore order, the server may not allow it: %w", err)
+		}
+	case "clustertemplate", "clustertemplates":
+		client := fulfillmentv1.NewClusterTemplatesClient(conn)
+		response, err := client.Get(ctx, &fulfillmentv1.ClusterTemplatesGetRequest{
+			Id: id,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get template: %w", err)
+		}
+		err = checkDeleted(kind, id, response.Object.GetMetadata())
+		if err != nil {
+			return err
+		}
+		_, err = client.Update(ctx, &fulfillmentv1.ClusterTemplatesUpdateRequest{
+			Object: &fulfillmentv1.ClusterTemplate{
+				Id:       id,
+				Metadata: &sharedv1.Metadata{},
+			},
+			UpdateMask: deletionMask,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore template, the server may not allow it: %w", err)
+		}
+	default:
+		return fmt.Errorf(
+			"unsupported object type '%s', valid types are 'cluster', 'clusterorder' and 'clustertemplate'",
+			kind,
+		)
+	}
+	return nil
+}
+
+// checkDeleted returns an error if the object doesn't have a deletion timestamp, as there is nothing to restore then.
+func checkDeleted(kind, id string, metadata *sharedv1.Metadata) error {
+	if metadata.GetDeletionTimestamp() == nil {
+		return fmt.Errorf("%s '%s' isn't deleted", kind, id)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package trash
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/cmd/trash/list"
+	"github.com/innabox/fulfillment-cli/internal/cmd/trash/restore"
+)
+
+func Cmd() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "trash",
+		Short: "Inspect and recover deleted objects",
+		Long: "Inspect and recover deleted objects. Objects aren't removed immediately when they are deleted: the " +
+			"server sets their deletion timestamp and keeps them while the resources are released. During that " +
+			"time they can be listed, and restored if the server allows it.",
+	}
+	result.AddCommand(list.Cmd())
+	result.AddCommand(restore.Cmd())
+	return result
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/version"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "version [flags]",
+		Short: "Display version information",
+		Long: "Display the version of the tool, and the version of the server if it reports it with the '" +
+			serverVersionHeader + "' header.",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	flags := result.Flags()
+	flags.BoolVar(
+		&runner.client,
+		"client",
+		false,
+		"Display only the version of the tool, without contacting the server",
+	)
+	return result
+}
+
+type runnerContext struct {
+	client bool
+}
+
+// serverVersionHeader is the name of the response header that the server may use to report its version.
+const serverVersionHeader = "server-version"
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Display the information about the tool:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "Client version:\t%s\n", unknown(version.Version))
+	fmt.Fprintf(writer, "Commit:\t%s\n", unknown(version.Commit))
+	fmt.Fprintf(writer, "Build date:\t%s\n", unknown(version.Date))
+	fmt.Fprintf(writer, "Go version:\t%s\n", runtime.Version())
+	writer.Flush()
+	if c.client {
+		return nil
+	}
+
+	// Get the configuration, and don't try to contact the server if there is none:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return nil
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Get and display the version of the server:
+	serverVersion, err := c.serverVersion(cmd.Context(), conn)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(writer, "Server:\t%s\n", cfg.Address)
+	fmt.Fprintf(writer, "Server version:\t%s\n", unknown(serverVersion))
+	writer.Flush()
+
+	// Warn if the major versions are different, as that usually means that they aren't compatible:
+	clientMajor := version.Major(version.Version)
+	serverMajor := version.Major(serverVersion)
+	if clientMajor != "" && serverMajor != "" && clientMajor != serverMajor {
+		fmt.Fprintf(
+			os.Stderr,
+			"Warning: the major version of the tool (%s) is different to the major version of the server "+
+				"(%s), some commands may not work correctly\n",
+			clientMajor, serverMajor,
+		)
+	}
+
+	return nil
+}
+
+// serverVersion sends a cheap request to the server and gets its version from the response headers. The API doesn't
+// have a method to get the version, so it returns an empty string if the server doesn't send that header.
+func (c *runnerContext) serverVersion(ctx context.Context, conn *grpc.ClientConn) (result string, err error) {
+	client := fulfillmentv1.NewClusterTemplatesClient(conn)
+	var header metadata.MD
+	_, err = client.List(
+		ctx,
+		&fulfillmentv1.ClusterTemplatesListRequest{
+			Limit: new(int32),
+		},
+		grpc.Header(&header),
+	)
+	if err != nil {
+		err = fmt.Errorf("failed to contact server: %w", err)
+		return
+	}
+	values := header.Get(serverVersionHeader)
+	if len(values) > 0 {
+		result = values[0]
+	}
+	return
+}
+
+// unknown returns the given value, or 'unknown' if it is empty.
+func unknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package whoami
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/innabox/fulfillment-cli/internal/auth"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/output"
+)
+
+func Cmd() *cobra.Command {
+	runner := &runnerContext{}
+	result := &cobra.Command{
+		Use:   "whoami",
+		Short: "Display the current identity",
+		Long: "Display the server in use and the identity contained in the stored token: subject, issuer, " +
+			"expiration and scopes. The token is decoded locally and its signature isn't verified, so this is " +
+			"intended to help debugging permission issues, not to check that the token is valid.",
+		Args: cobra.NoArgs,
+		RunE: runner.run,
+	}
+	return result
+}
+
+type runnerContext struct {
+}
+
+func (c *runnerContext) run(cmd *cobra.Command, args []string) error {
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Display the server, and stop if there is no token:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer writer.Flush()
+	fmt.Fprintf(writer, "Server:\t%s\n", cfg.Address)
+	if cfg.Token == "" {
+		fmt.Fprintf(writer, "Identity:\tnone, not using a token\n")
+		return nil
+	}
+	claims, err := auth.ParseClaims(cfg.Token)
+	if err != nil {
+		fmt.Fprintf(writer, "Identity:\tunknown, %v\n", err)
+		return nil
+	}
+
+	// Display the claims:
+	fmt.Fprintf(writer, "Subject:\t%s\n", dash(claims.Subject))
+	if claims.Username != "" {
+		fmt.Fprintf(writer, "Username:\t%s\n", claims.Username)
+	}
+	if claims.Email != "" {
+		fmt.Fprintf(writer, "Email:\t%s\n", claims.Email)
+	}
+	fmt.Fprintf(writer, "Issuer:\t%s\n", dash(claims.Issuer))
+	fmt.Fprintf(writer, "Audience:\t%s\n", dash(strings.Join(claims.Audience, ", ")))
+	fmt.Fprintf(writer, "Scopes:\t%s\n", dash(strings.Join(claims.Scopes, " ")))
+	if !claims.IssuedAt.IsZero() {
+		fmt.Fprintf(writer, "Issued:\t%s\n", output.Time(claims.IssuedAt))
+	}
+	switch {
+	case claims.Expiry.IsZero():
+		fmt.Fprintf(writer, "Expires:\tnever\n")
+	case claims.Expiry.After(time.Now()):
+		fmt.Fprintf(writer, "Expires:\t%s\n", output.Time(claims.Expiry))
+	case cfg.RefreshToken != "":
+		fmt.Fprintf(writer, "Expires:\t%s (expired, will be refreshed)\n", output.Time(claims.Expiry))
+	default:
+		fmt.Fprintf(writer, "Expires:\t%s (expired, run the 'login' command)\n", output.Time(claims.Expiry))
+	}
+
+	return nil
+}
+
+func dash(text string) string {
+	if text == "" {
+		return "-"
+	}
+	return text
+}
@@ -0,0 +1,307 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package compat compares the API descriptors compiled into the tool with the descriptors that the server advertises
+// using the gRPC reflection service.
+package compat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	_ "github.com/innabox/fulfillment-cli/internal/api/events/v1"
+	_ "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+)
+
+// packages are the protobuf packages that are compared. Other packages that the server may have, for example the
+// administration API, aren't used by the tool.
+var packages = []protoreflect.FullName{
+	"events.v1",
+	"fulfillment.v1",
+}
+
+// Side indicates where an element of the API exists.
+type Side string
+
+const (
+	// OnlyClient means that the element exists in the tool, but not in the server. That usually means that the
+	// server is older than the tool.
+	OnlyClient Side = "client"
+
+	// OnlyServer means that the element exists in the server, but not in the tool. That usually means that the tool
+	// is older than the server.
+	OnlyServer Side = "server"
+)
+
+// Difference describes an element of the API that exists only in one side.
+type Difference struct {
+	// Kind is the kind of element, for example 'method' or 'field'.
+	Kind string
+
+	// Name is the fully qualified name of the element.
+	Name string
+
+	// Side is where the element exists.
+	Side Side
+}
+
+// Check retrieves the descriptors of the services from the server and compares them with the descriptors compiled
+// into the tool. The result is sorted by name.
+func Check(ctx context.Context, conn *grpc.ClientConn) (result []Difference, err error) {
+	remote, err := fetch(ctx, conn)
+	if err != nil {
+		return
+	}
+	checker := &checker{
+		visited: map[protoreflect.FullName]bool{},
+	}
+	for _, local := range localServices() {
+		checker.compareService(local, remote[local.FullName()])
+		delete(remote, local.FullName())
+	}
+	for _, service := range remote {
+		checker.add("service", service.FullName(), OnlyServer)
+	}
+	result = checker.differences
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return
+}
+
+// localServices returns the services of the compared packages that are compiled into the tool.
+func localServices() []protoreflect.ServiceDescriptor {
+	var result []protoreflect.ServiceDescriptor
+	protoregistry.GlobalFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		if !compared(file.Package()) {
+			return true
+		}
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			result = append(result, services.Get(i))
+		}
+		return true
+	})
+	return result
+}
+
+func compared(name protoreflect.FullName) bool {
+	for _, pkg := range packages {
+		if name == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// fetch uses the reflection service of the server to get the descriptors of the services of the compared packages.
+func fetch(ctx context.Context, conn *grpc.ClientConn) (result map[protoreflect.FullName]protoreflect.ServiceDescriptor,
+	err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	client := reflectionv1.NewServerReflectionClient(conn)
+	stream, err := client.ServerReflectionInfo(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to start reflection stream: %w", err)
+		return
+	}
+	defer stream.CloseSend()
+	call := func(request *reflectionv1.ServerReflectionRequest) (*reflectionv1.ServerReflectionResponse, error) {
+		err := stream.Send(request)
+		if err != nil {
+			return nil, err
+		}
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		failure := response.GetErrorResponse()
+		if failure != nil {
+			return nil, fmt.Errorf("%s", failure.ErrorMessage)
+		}
+		return response, nil
+	}
+
+	// Get the names of the services:
+	response, err := call(&reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_ListServices{},
+	})
+	if err == io.EOF {
+		err = fmt.Errorf("server closed the reflection stream")
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to list services, check that the server supports reflection: %w", err)
+		return
+	}
+	var names []protoreflect.FullName
+	for _, service := range response.GetListServicesResponse().GetService() {
+		name := protoreflect.FullName(service.Name)
+		if compared(name.Parent()) {
+			names = append(names, name)
+		}
+	}
+
+	// Get the files that contain the services. The server sends also the dependencies of those files, but it may
+	// skip the ones that it already sent in the same stream, so all of them need to be collected before building
+	// the descriptors.
+	files := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, name := range names {
+		response, err = call(&reflectionv1.ServerReflectionRequest{
+			MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{
+				FileContainingSymbol: string(name),
+			},
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to get descriptor of service '%s': %w", name, err)
+			return
+		}
+		for _, data := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			file := &descriptorpb.FileDescriptorProto{}
+			err = proto.Unmarshal(data, file)
+			if err != nil {
+				err = fmt.Errorf("failed to parse descriptor of service '%s': %w", name, err)
+				return
+			}
+			files[file.GetName()] = file
+		}
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, file := range files {
+		set.File = append(set.File, file)
+	}
+	registry, err := protodesc.NewFiles(set)
+	if err != nil {
+		err = fmt.Errorf("failed to build descriptors sent by the server: %w", err)
+		return
+	}
+	result = map[protoreflect.FullName]protoreflect.ServiceDescriptor{}
+	for _, name := range names {
+		var descriptor protoreflect.Descriptor
+		descriptor, err = registry.FindDescriptorByName(name)
+		if err != nil {
+			err = fmt.Errorf("failed to find descriptor of service '%s': %w", name, err)
+			return
+		}
+		service, ok := descriptor.(protoreflect.ServiceDescriptor)
+		if !ok {
+			err = fmt.Errorf("descriptor '%s' sent by the server isn't a service", name)
+			return
+		}
+		result[name] = service
+	}
+	return
+}
+
+// checker contains the state of a comparison.
+type checker struct {
+	differences []Difference
+	visited     map[protoreflect.FullName]bool
+}
+
+func (c *checker) add(kind string, name protoreflect.FullName, side Side) {
+	c.differences = append(c.differences, Difference{
+		Kind: kind,
+		Name: string(name),
+		Side: side,
+	})
+}
+
+func (c *checker) compareService(local, remote protoreflect.ServiceDescriptor) {
+	if remote == nil {
+		c.add("service", local.FullName(), OnlyClient)
+		return
+	}
+	localMethods := local.Methods()
+	remoteMethods := remote.Methods()
+	for i := 0; i < localMethods.Len(); i++ {
+		localMethod := localMethods.Get(i)
+		remoteMethod := remoteMethods.ByName(localMethod.Name())
+		if remoteMethod == nil {
+			c.add("method", localMethod.FullName(), OnlyClient)
+			continue
+		}
+		c.compareMessage(localMethod.Input(), remoteMethod.Input())
+		c.compareMessage(localMethod.Output(), remoteMethod.Output())
+	}
+	for i := 0; i < remoteMethods.Len(); i++ {
+		remoteMethod := remoteMethods.Get(i)
+		if localMethods.ByName(remoteMethod.Name()) == nil {
+			c.add("method", remoteMethod.FullName(), OnlyServer)
+		}
+	}
+}
+
+// compareMessage compares the fields of two versions of a message, and then the types of those fields. Fields are
+// matched by number, as that is what matters for compatibility, and a field whose name is different in each side is
+// reported as missing in both.
+func (c *checker) compareMessage(local, remote protoreflect.MessageDescriptor) {
+	if c.visited[local.FullName()] || strings.HasPrefix(string(local.FullName()), "google.") {
+		return
+	}
+	c.visited[local.FullName()] = true
+	localFields := local.Fields()
+	remoteFields := remote.Fields()
+	for i := 0; i < localFields.Len(); i++ {
+		localField := localFields.Get(i)
+		remoteField := remoteFields.ByNumber(localField.Number())
+		if remoteField == nil || remoteField.Name() != localField.Name() {
+			c.add("field", localField.FullName(), OnlyClient)
+			continue
+		}
+		switch {
+		case localField.Message() != nil && remoteField.Message() != nil:
+			c.compareMessage(localField.Message(), remoteField.Message())
+		case localField.Enum() != nil && remoteField.Enum() != nil:
+			c.compareEnum(localField.Enum(), remoteField.Enum())
+		}
+	}
+	for i := 0; i < remoteFields.Len(); i++ {
+		remoteField := remoteFields.Get(i)
+		localField := localFields.ByNumber(remoteField.Number())
+		if localField == nil || localField.Name() != remoteField.Name() {
+			c.add("field", remoteField.FullName(), OnlyServer)
+		}
+	}
+}
+
+func (c *checker) compareEnum(local, remote protoreflect.EnumDescriptor) {
+	if c.visited[local.FullName()] {
+		return
+	}
+	c.visited[local.FullName()] = true
+	localValues := local.Values()
+	remoteValues := remote.Values()
+	for i := 0; i < localValues.Len(); i++ {
+		value := localValues.Get(i)
+		if remoteValues.ByName(value.Name()) == nil {
+			c.add("enum value", value.FullName(), OnlyClient)
+		}
+	}
+	for i := 0; i < remoteValues.Len(); i++ {
+		value := remoteValues.Get(i)
+		if localValues.ByName(value.Name()) == nil {
+			c.add("enum value", value.FullName(), OnlyServer)
+		}
+	}
+}
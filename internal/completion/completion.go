@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package completion contains the functions that the shell completion uses to suggest the identifiers of objects.
+// The suggestions include a description, like the state of the object, that shells like zsh and fish display next
+// to each identifier.
+package completion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paging"
+)
+
+// timeout is the maximum time spent retrieving suggestions, so that the shell doesn't block for a long time when the
+// server is slow or unreachable.
+const timeout = 5 * time.Second
+
+// Func is the type of the functions used by cobra to complete arguments and flag values.
+type Func func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// Clusters suggests the identifiers of the clusters, described with their state and API URL.
+func Clusters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return suggest(cmd, toComplete, func(ctx context.Context, conn *grpc.ClientConn) (result []string, err error) {
+		client := fulfillmentv1.NewClustersClient(conn)
+		clusters, err := paging.ListAll(ctx, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.Cluster, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClustersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+		if err != nil {
+			return
+		}
+		for _, cluster := range clusters {
+			result = append(result, entry(
+				cluster.Id,
+				output.Enum(cluster.GetStatus().GetState()),
+				cluster.GetStatus().GetApiUrl(),
+			))
+		}
+		return
+	})
+}
+
+// ClusterOrders suggests the identifiers of the cluster orders, described with their state and template.
+func ClusterOrders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return suggest(cmd, toComplete, func(ctx context.Context, conn *grpc.ClientConn) (result []string, err error) {
+		client := fulfillmentv1.NewClusterOrdersClient(conn)
+		orders, err := paging.ListAll(ctx, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.ClusterOrder, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterOrdersListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+		if err != nil {
+			return
+		}
+		for _, order := range orders {
+			result = append(result, entry(
+				order.Id,
+				output.Enum(order.GetStatus().GetState()),
+				order.GetSpec().GetTemplateId(),
+			))
+		}
+		return
+	})
+}
+
+// ClusterTemplates suggests the identifiers of the cluster templates, described with their titles.
+func ClusterTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective) {
+	return suggest(cmd, toComplete, func(ctx context.Context, conn *grpc.ClientConn) (result []string, err error) {
+		client := fulfillmentv1.NewClusterTemplatesClient(conn)
+		templates, err := paging.ListAll(ctx, func(ctx context.Context, offset,
+			limit int32) ([]*fulfillmentv1.ClusterTemplate, int32, error) {
+			response, err := client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+				Offset: &offset,
+				Limit:  &limit,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Items, response.GetTotal(), nil
+		})
+		if err != nil {
+			return
+		}
+		for _, template := range templates {
+			result = append(result, entry(template.Id, template.Title))
+		}
+		return
+	})
+}
+
+// First returns a completion function that uses the given function only for the first argument, and suggests
+// nothing for the rest.
+func First(function Func) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return function(cmd, args, toComplete)
+	}
+}
+
+// suggest connects to the server, calls the given function to get the candidates and returns the ones that start
+// with the text that is being completed. Errors are reported with the error directive, as completion has no other
+// way to display them.
+func suggest(cmd *cobra.Command, toComplete string, list func(context.Context, *grpc.ClientConn) ([]string,
+	error)) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Load()
+	if err != nil || cfg.Address == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	conn, err := cfg.Connect()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer conn.Close()
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	candidates, err := list(ctx, conn)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to list candidates: %v", err), true)
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var result []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			result = append(result, candidate)
+		}
+	}
+	return result, cobra.ShellCompDirectiveNoFileComp
+}
+
+// entry builds a completion entry with the identifier and a description made of the non empty details.
+func entry(id string, details ...string) string {
+	var parts []string
+	for _, detail := range details {
+		if detail != "" {
+			parts = append(parts, detail)
+		}
+	}
+	if len(parts) == 0 {
+		return id
+	}
+	return id + "\t" + strings.Join(parts, ", ")
+}
@@ -14,11 +14,16 @@ language governing permissions and limitations under the License.
 package config
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
@@ -26,19 +31,278 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/credentials/oauth"
 
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+	"github.com/innabox/fulfillment-cli/internal/auth"
+	"github.com/innabox/fulfillment-cli/internal/interceptors"
+	"github.com/innabox/fulfillment-cli/internal/paths"
+	"github.com/innabox/fulfillment-cli/internal/proxy"
+	"github.com/innabox/fulfillment-cli/internal/secrets"
+	"github.com/innabox/fulfillment-cli/internal/terminal"
+	"github.com/innabox/fulfillment-cli/internal/warnings"
+
 	experimentalcredentials "google.golang.org/grpc/experimental/credentials"
 )
 
 // Config is the type used to store the configuration of the client.
 type Config struct {
+	// Version is the version of the format of the configuration file. It is set automatically when the file is
+	// saved.
+	Version int `json:"version,omitempty"`
+
 	Token     string `json:"token,omitempty"`
 	Plaintext bool   `json:"plaintext,omitempty"`
 	Insecure  bool   `json:"insecure,omitempty"`
 	Address   string `json:"address,omitempty"`
+
+	// RefreshToken is the OAuth2 refresh token obtained when logging in with an OpenID Connect provider.
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// TLSCert and TLSKey are the paths of the files containing the client certificate and key used for mutual TLS
+	// authentication. Both are required if one is set.
+	TLSCert string `json:"tls_cert,omitempty"`
+	TLSKey  string `json:"tls_key,omitempty"`
+
+	// TLSCA is the path of the file containing the certificates of the authorities that are trusted to sign the
+	// certificate of the server. The default is to use the certificates of the system.
+	TLSCA string `json:"tls_ca,omitempty"`
+
+	// Proxy is the URL of the HTTP, HTTPS or SOCKS5 proxy used to connect to the server, for example
+	// 'http://proxy.example.com:3128'. The default is to use the proxy of the 'HTTPS_PROXY' environment variable, if
+	// any. In both cases the 'NO_PROXY' environment variable is honored.
+	Proxy string `json:"proxy,omitempty"`
+
+	// SuppressInsecureWarning disables the warning that is written each time that a connection is created with TLS
+	// or verification of certificates disabled.
+	SuppressInsecureWarning bool `json:"suppress_insecure_warning,omitempty"`
+
+	// Timeout is the maximum time that each call to the server can take, for example '30s'. The default is no
+	// limit.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Retries is the number of times that calls that only read data are retried when the server is unavailable. The
+	// default is to not retry.
+	Retries int `json:"retries,omitempty"`
+
+	// RetryBackoff is the delay before the first retry, for example '2s'. It is doubled after each retry. The default
+	// is one second.
+	RetryBackoff string `json:"retry_backoff,omitempty"`
+
+	// SaveSnapshots enables saving a snapshot of each object listed by the 'get' command, so that the 'changes'
+	// command can find what changed in them. It is disabled by default because it writes to disk for every listed
+	// object. The 'describe' and 'changes' commands always save snapshots of the objects they retrieve.
+	SaveSnapshots bool `json:"save_snapshots,omitempty"`
+
+	// CredentialStore indicates where the tokens are stored. When it is 'keyring' the tokens are kept in the keyring
+	// of the operating system and they aren't written to the configuration file. The default is to write them to the
+	// configuration file.
+	CredentialStore string `json:"credential_store,omitempty"`
+
+	// OIDC contains the details of the OpenID Connect provider used to log in, if any.
+	OIDC *OIDCConfig `json:"oidc,omitempty"`
+
+	// Telemetry contains the settings for the collection of anonymous usage metrics. It is nil unless the user
+	// explicitly enabled or disabled it.
+	Telemetry *TelemetryConfig `json:"telemetry,omitempty"`
+
+	// Experimental contains the settings that control the use of experimental features.
+	Experimental *ExperimentalConfig `json:"experimental,omitempty"`
+
+	// DefaultFilters contains the filters that are automatically added to the requests that list objects.
+	DefaultFilters *DefaultFiltersConfig `json:"default_filters,omitempty"`
+
+	// Hooks contains the commands that are executed before and after the operations that modify objects.
+	Hooks *HooksConfig `json:"hooks,omitempty"`
+
+	// Columns contains additional columns for the tables displayed by the 'get' command.
+	Columns *ColumnsConfig `json:"columns,omitempty"`
+
+	// Aliases contains the commands that can be used as shortcuts for other commands. The key is the name of the
+	// alias and the value is the command line that replaces it, for example 'gc' and
+	// 'get clusters -o wide --sort-by cluster.metadata.creation_timestamp'. Values can be quoted as in the shell.
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// DefaultFlags contains the flags that are automatically added to commands. The key is the path of the command,
+	// without the name of the tool, for example 'get cluster', and the value contains the flags, for example
+	// '-o wide'. Flags given in the command line replace the default flags with the same name.
+	DefaultFlags map[string]string `json:"default_flags,omitempty"`
+
+	// unknown contains the settings of the configuration file that don't correspond to any of the fields above.
+	// They aren't preserved when the configuration is saved.
+	unknown []unknownSetting
+}
+
+// OIDCConfig contains the details of the OpenID Connect provider used to log in.
+type OIDCConfig struct {
+	Issuer             string `json:"issuer,omitempty"`
+	ClientID           string `json:"client_id,omitempty"`
+	TokenEndpoint      string `json:"token_endpoint,omitempty"`
+	RevocationEndpoint string `json:"revocation_endpoint,omitempty"`
+}
+
+// TelemetryConfig contains the settings for the collection of anonymous usage metrics.
+type TelemetryConfig struct {
+	Enabled  bool   `json:"enabled,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// ExperimentalConfig contains the settings that control the use of experimental features.
+type ExperimentalConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// DefaultFiltersConfig contains the filters that are automatically added to the requests that list objects of each
+// type, for example "state != 'FAILED'". They are combined with the filters given in the command line, if any.
+type DefaultFiltersConfig struct {
+	Cluster         string `json:"cluster,omitempty"`
+	ClusterOrder    string `json:"clusterorder,omitempty"`
+	ClusterTemplate string `json:"clustertemplate,omitempty"`
+}
+
+// ColumnsConfig contains the additional columns for the tables of each type of object. They are appended to the
+// default columns, or to the columns defined in the table files of the configuration directory.
+type ColumnsConfig struct {
+	Cluster         []ColumnConfig `json:"cluster,omitempty"`
+	ClusterOrder    []ColumnConfig `json:"clusterorder,omitempty"`
+	ClusterTemplate []ColumnConfig `json:"clustertemplate,omitempty"`
+}
+
+// ColumnConfig is the definition of an additional column. The value is a CEL expression, for example
+// 'cluster.metadata.creation_timestamp'.
+type ColumnConfig struct {
+	Header string `json:"header"`
+	Value  string `json:"value"`
+	Width  int    `json:"width,omitempty"`
+	Max    int    `json:"max,omitempty"`
+	Align  string `json:"align,omitempty"`
+	Wide   bool   `json:"wide,omitempty"`
+}
+
+// For returns the additional columns for the given type of object. It is safe to call it when the configuration
+// doesn't have a columns section.
+func (c *ColumnsConfig) For(kind string) []ColumnConfig {
+	if c == nil {
+		return nil
+	}
+	switch kind {
+	case "cluster":
+		return c.Cluster
+	case "clusterorder":
+		return c.ClusterOrder
+	case "clustertemplate":
+		return c.ClusterTemplate
+	default:
+		return nil
+	}
+}
+
+// HooksConfig contains the commands that are executed before and after the operations that modify objects. The
+// commands are executed with 'sh -c', receive the description of the operation in JSON format in the standard input,
+// and the name of the hook, the operation, the type and the identifier of the object in the FULFILLMENT_CLI_HOOK,
+// FULFILLMENT_CLI_OPERATION, FULFILLMENT_CLI_TYPE and FULFILLMENT_CLI_ID environment variables. If a command that runs
+// before an operation fails the operation isn't executed.
+type HooksConfig struct {
+	PreCreate  string `json:"pre_create,omitempty"`
+	PostCreate string `json:"post_create,omitempty"`
+	PreUpdate  string `json:"pre_update,omitempty"`
+	PostUpdate string `json:"post_update,omitempty"`
+	PreDelete  string `json:"pre_delete,omitempty"`
+	PostDelete string `json:"post_delete,omitempty"`
+}
+
+// defaultFiltersDisabled indicates if the default filters should be ignored. It is set with DisableDefaultFilters.
+var defaultFiltersDisabled bool
+
+// DisableDefaultFilters disables the default filters for the connections created after calling it. This is intended
+// for the '--no-default-filters' command line flag.
+func DisableDefaultFilters() {
+	defaultFiltersDisabled = true
+}
+
+// debugGRPC is the level of the debug log of the gRPC calls. It is set with SetDebugGRPC.
+var debugGRPC string
+
+// SetDebugGRPC enables the debug log of the gRPC calls for the connections created after calling it. The level is
+// one of the values of interceptors.DebugLevels, or empty to disable it. This is intended for the '--debug-grpc'
+// command line flag.
+func SetDebugGRPC(level string) {
+	debugGRPC = level
+}
+
+// recordGRPC and replayGRPC are the directories where the gRPC calls are recorded or replayed from. They are set with
+// SetRecordGRPC and SetReplayGRPC.
+var (
+	recordGRPC string
+	replayGRPC string
+)
+
+// SetRecordGRPC enables the recording of the gRPC calls to the given directory for the connections created after
+// calling it, or disables it if the directory is empty. This is intended for the '--record-grpc' command line flag.
+func SetRecordGRPC(dir string) {
+	recordGRPC = dir
+}
+
+// SetReplayGRPC makes the connections created after calling it answer the gRPC calls with the ones recorded in the
+// given directory, without contacting the server, or disables that if the directory is empty. This is intended for
+// the '--replay-grpc' command line flag.
+func SetReplayGRPC(dir string) {
+	replayGRPC = dir
 }
 
-// Load loads the configuration from the configuration file.
+// defaultRetryBackoff is the delay before the first retry when the configuration doesn't specify it.
+const defaultRetryBackoff = time.Second
+
+// Names of the secrets used to store the tokens when the credential store is the keyring:
+const (
+	tokenSecret        = "token"
+	refreshTokenSecret = "refresh_token"
+)
+
+// Load loads the configuration from the configuration file. It fails if the file contains unknown settings.
 func Load() (cfg *Config, err error) {
+	cfg, err = LoadUnchecked()
+	if err != nil {
+		return
+	}
+	err = cfg.Check()
+	if err != nil {
+		file, _ := Location()
+		err = fmt.Errorf(
+			"failed to parse config file '%s': %w, use the 'config unset' command to remove them",
+			file, err,
+		)
+		cfg = nil
+	}
+	return
+}
+
+// LoadUnchecked is like Load, but it doesn't fail when the configuration file contains unknown settings. This is
+// intended for the commands that are used to repair the configuration file, like 'config unset', so that they keep
+// working when it contains a typo. Those commands should use the Unknown method to report the unknown settings, and
+// note that they are removed when the configuration is saved.
+func LoadUnchecked() (cfg *Config, err error) {
+	cfg, err = LoadSettings()
+	if err != nil {
+		return
+	}
+	if cfg.CredentialStore == secrets.StoreKeyring {
+		cfg.Token, err = secrets.Get(tokenSecret)
+		if err != nil {
+			return
+		}
+		cfg.RefreshToken, err = secrets.Get(refreshTokenSecret)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// LoadSettings is like Load, but it doesn't retrieve the tokens from the keyring and it ignores unknown settings. This
+// is intended for code that runs for every command and doesn't connect to the server, like the expansion of aliases,
+// the checks of experimental features or the telemetry, so that the keyring isn't accessed when it isn't needed, and
+// so that a typo in the configuration file doesn't prevent the use of the commands that can repair it. The commands
+// that use Load will report the unknown settings.
+func LoadSettings() (cfg *Config, err error) {
 	file, err := Location()
 	if err != nil {
 		return
@@ -62,7 +326,7 @@ func Load() (cfg *Config, err error) {
 	if len(data) == 0 {
 		return
 	}
-	err = json.Unmarshal(data, cfg)
+	err = parse(data, cfg)
 	if err != nil {
 		err = fmt.Errorf("failed to parse config file '%s': %v", file, err)
 		return
@@ -70,12 +334,46 @@ func Load() (cfg *Config, err error) {
 	return
 }
 
+// Unknown returns the descriptions of the settings of the configuration file that aren't known, for example
+// "unknown setting 'adress' (did you mean 'address'?)".
+func (c *Config) Unknown() []string {
+	result := make([]string, len(c.unknown))
+	for i, setting := range c.unknown {
+		result[i] = setting.String()
+	}
+	return result
+}
+
+// Check returns an error if the configuration file contains unknown settings.
+func (c *Config) Check() error {
+	problems := c.Unknown()
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
 // Save saves the given configuration to the configuration file.
 func Save(cfg *Config) error {
 	file, err := Location()
 	if err != nil {
 		return err
 	}
+	cfg.Version = CurrentVersion
+	if cfg.CredentialStore == secrets.StoreKeyring {
+		err = secrets.Set(tokenSecret, cfg.Token)
+		if err != nil {
+			return err
+		}
+		err = secrets.Set(refreshTokenSecret, cfg.RefreshToken)
+		if err != nil {
+			return err
+		}
+		stripped := *cfg
+		stripped.Token = ""
+		stripped.RefreshToken = ""
+		cfg = &stripped
+	}
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %v", err)
@@ -92,13 +390,70 @@ func Save(cfg *Config) error {
 	return nil
 }
 
+// Delete deletes the configuration file, and the tokens stored in the keyring if the configuration says that they are
+// stored there.
+func Delete(cfg *Config) error {
+	if cfg.CredentialStore == secrets.StoreKeyring {
+		err := secrets.Delete(tokenSecret)
+		if err != nil {
+			return err
+		}
+		err = secrets.Delete(refreshTokenSecret)
+		if err != nil {
+			return err
+		}
+	}
+	file, err := Location()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(file)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete config file '%s': %v", file, err)
+	}
+	return nil
+}
+
 // Location returns the location of the configuration file.
 func Location() (result string, err error) {
-	configDir, err := os.UserConfigDir()
+	configDir, err := paths.ConfigDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(configDir, "config.json")
+	return
+}
+
+// InsecureWarning returns a message explaining that TLS or the verification of certificates is disabled, or an
+// empty string if the configuration is secure.
+func (c *Config) InsecureWarning() string {
+	var problem string
+	switch {
+	case c.Plaintext:
+		problem = "TLS is disabled"
+	case c.Insecure:
+		problem = "verification of TLS certificates is disabled"
+	default:
+		return ""
+	}
+	return fmt.Sprintf(
+		"Warning: %s for '%s', set 'suppress_insecure_warning' in the configuration file to hide this message",
+		problem, c.Address,
+	)
+}
+
+// LoadCA loads the certificates of the trusted authorities from the given PEM file.
+func LoadCA(file string) (result *x509.CertPool, err error) {
+	data, err := os.ReadFile(file)
 	if err != nil {
+		err = fmt.Errorf("failed to read CA file '%s': %v", file, err)
 		return
 	}
-	result = filepath.Join(configDir, "fulfillment-cli", "config.json")
+	result = x509.NewCertPool()
+	if !result.AppendCertsFromPEM(data) {
+		err = fmt.Errorf("CA file '%s' doesn't contain any PEM encoded certificate", file)
+		result = nil
+	}
 	return
 }
 
@@ -106,6 +461,14 @@ func Location() (result string, err error) {
 func (c *Config) Connect() (result *grpc.ClientConn, err error) {
 	var dialOpts []grpc.DialOption
 
+	// Remind the user that the connection isn't secure, so that it isn't left like that permanently by mistake:
+	if !c.SuppressInsecureWarning {
+		warning := c.InsecureWarning()
+		if warning != "" {
+			fmt.Fprintf(os.Stderr, "%s\n", terminal.Dim(os.Stderr, warning))
+		}
+	}
+
 	// Configure use of TLS:
 	var transportCreds credentials.TransportCredentials
 	if c.Plaintext {
@@ -115,6 +478,24 @@ func (c *Config) Connect() (result *grpc.ClientConn, err error) {
 		if c.Insecure {
 			tlsConfig.InsecureSkipVerify = true
 		}
+		if c.TLSCert != "" || c.TLSKey != "" {
+			var certificate tls.Certificate
+			certificate, err = tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+			if err != nil {
+				err = fmt.Errorf(
+					"failed to load client certificate '%s' and key '%s': %v",
+					c.TLSCert, c.TLSKey, err,
+				)
+				return
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+		if c.TLSCA != "" {
+			tlsConfig.RootCAs, err = LoadCA(c.TLSCA)
+			if err != nil {
+				return
+			}
+		}
 
 		// TODO: This should have been the non-experimental package, but we need to use this one because
 		// currently the OpenShift router doesn't seem to support ALPN, and the regular credentials package
@@ -130,17 +511,122 @@ func (c *Config) Connect() (result *grpc.ClientConn, err error) {
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(transportCreds))
 	}
 
-	// Confgure use of token:
+	// Configure the proxy. Note that the passthrough resolver is used so that the name of the server is sent to the
+	// proxy instead of being resolved locally, as that may not be possible behind a proxy.
+	target := c.Address
+	host := strings.TrimPrefix(target, "dns:///")
+	if _, _, splitErr := net.SplitHostPort(host); splitErr != nil {
+		host = net.JoinHostPort(host, "443")
+	}
+	proxyURL, err := proxy.Find(c.Proxy, host)
+	if err != nil {
+		return
+	}
+	if proxyURL != nil {
+		var dialer func(ctx context.Context, address string) (net.Conn, error)
+		dialer, err = proxy.Dialer(proxyURL)
+		if err != nil {
+			return
+		}
+		dialOpts = append(dialOpts, grpc.WithContextDialer(dialer))
+		target = "passthrough:///" + host
+	}
+
+	// Confgure use of token. If the token was obtained from an OpenID Connect provider then it is refreshed when it
+	// expires, and the new tokens are saved to the configuration.
 	if c.Token != "" {
-		token := &oauth2.Token{
-			AccessToken: c.Token,
+		var source oauth2.TokenSource
+		if c.RefreshToken != "" && c.OIDC != nil && c.OIDC.TokenEndpoint != "" {
+			source = auth.NewTokenSource(
+				c.OIDC.ClientID, c.OIDC.TokenEndpoint, c.Token, c.RefreshToken,
+				func(accessToken, refreshToken string) error {
+					c.Token = accessToken
+					c.RefreshToken = refreshToken
+					return Save(c)
+				},
+			)
+		} else {
+			source = oauth2.StaticTokenSource(&oauth2.Token{
+				AccessToken: c.Token,
+			})
 		}
 		creds := oauth.TokenSource{
-			TokenSource: oauth2.StaticTokenSource(token),
+			TokenSource: source,
 		}
 		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(creds))
 	}
 
-	result, err = grpc.NewClient(c.Address, dialOpts...)
+	// Collect the warnings sent by the server, so that they can be displayed when the command finishes:
+	dialOpts = append(
+		dialOpts,
+		grpc.WithChainUnaryInterceptor(interceptors.Warnings(warnings.Add)),
+		grpc.WithChainStreamInterceptor(interceptors.StreamWarnings(warnings.Add)),
+	)
+
+	// Honor the requests of the server to slow down:
+	dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(
+		interceptors.Throttling(os.Stderr),
+	))
+
+	// Set the deadlines and retry the calls that fail because the server is unavailable:
+	var timeout time.Duration
+	if c.Timeout != "" {
+		timeout, err = time.ParseDuration(c.Timeout)
+		if err != nil {
+			err = fmt.Errorf("timeout '%s' isn't valid: %v", c.Timeout, err)
+			return
+		}
+	}
+	backoff := defaultRetryBackoff
+	if c.RetryBackoff != "" {
+		backoff, err = time.ParseDuration(c.RetryBackoff)
+		if err != nil {
+			err = fmt.Errorf("retry backoff '%s' isn't valid: %v", c.RetryBackoff, err)
+			return
+		}
+	}
+	dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(
+		interceptors.Retry(timeout, c.Retries, backoff, os.Stderr),
+	))
+
+	// Add the default filters to the list requests:
+	if c.DefaultFilters != nil && !defaultFiltersDisabled {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(
+			interceptors.DefaultFilters(map[string]string{
+				fulfillmentv1.Clusters_List_FullMethodName:         c.DefaultFilters.Cluster,
+				fulfillmentv1.ClusterOrders_List_FullMethodName:    c.DefaultFilters.ClusterOrder,
+				fulfillmentv1.ClusterTemplates_List_FullMethodName: c.DefaultFilters.ClusterTemplate,
+			}),
+		))
+	}
+
+	// Log the calls if requested. This is the last interceptor of the chain, except the ones that record and replay
+	// calls, so that what is logged is what is actually sent to the server.
+	if debugGRPC != "" {
+		dialOpts = append(
+			dialOpts,
+			grpc.WithChainUnaryInterceptor(interceptors.Debug(os.Stderr, debugGRPC)),
+			grpc.WithChainStreamInterceptor(interceptors.StreamDebug(os.Stderr, debugGRPC)),
+		)
+	}
+
+	// Record the calls, or answer them with calls recorded previously, if requested. These go after the debug log,
+	// so that the replayed calls are also logged.
+	switch {
+	case recordGRPC != "":
+		dialOpts = append(
+			dialOpts,
+			grpc.WithChainUnaryInterceptor(interceptors.Record(recordGRPC)),
+			grpc.WithChainStreamInterceptor(interceptors.StreamRecord(recordGRPC)),
+		)
+	case replayGRPC != "":
+		dialOpts = append(
+			dialOpts,
+			grpc.WithChainUnaryInterceptor(interceptors.Replay(replayGRPC)),
+			grpc.WithChainStreamInterceptor(interceptors.StreamReplay(replayGRPC)),
+		)
+	}
+
+	result, err = grpc.NewClient(target, dialOpts...)
 	return
 }
@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CurrentVersion is the version of the format of the configuration file written by this version of the tool.
+const CurrentVersion = 1
+
+// migration updates the raw content of the configuration file from one version to the next.
+type migration func(data map[string]any) error
+
+// migrations contains the migrations of the format of the configuration file. The element with index i converts the
+// content from version i to version i+1, so new migrations must be added at the end, and CurrentVersion incremented.
+var migrations = []migration{
+	// Version 0 is the format used before the version was added to the file, the only change is the addition of the
+	// version itself.
+	func(data map[string]any) error {
+		return nil
+	},
+}
+
+// unknownSetting describes a setting of the configuration file that doesn't correspond to any field of the
+// configuration, usually because of a typo.
+type unknownSetting struct {
+	// Key is the complete path of the setting, for example 'oidc.isuer' or 'columns.cluster[1].vaule'.
+	Key string
+
+	// Suggestion is the complete path of the most similar setting, if it is similar enough to be a typo.
+	Suggestion string
+}
+
+// String returns the description of the problem.
+func (s unknownSetting) String() string {
+	result := fmt.Sprintf("unknown setting '%s'", s.Key)
+	if s.Suggestion != "" {
+		result += fmt.Sprintf(" (did you mean '%s'?)", s.Suggestion)
+	}
+	return result
+}
+
+// parse checks the content of the configuration file, migrates it to the current version and decodes it. Unknown
+// settings aren't an error here, they are saved in the configuration and reported by the Check method.
+func parse(data []byte, cfg *Config) error {
+	var raw map[string]any
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		raw = map[string]any{}
+	}
+
+	// Get the version, files without it were written before it was introduced:
+	version := 0
+	value, ok := raw["version"]
+	if ok {
+		number, ok := value.(float64)
+		if !ok || number < 0 || number != math.Trunc(number) {
+			return fmt.Errorf("version should be a non negative integer, but it is '%v'", value)
+		}
+		version = int(number)
+	}
+	if version > CurrentVersion {
+		return fmt.Errorf(
+			"version %d is newer than version %d supported by this version of the tool, update the tool",
+			version, CurrentVersion,
+		)
+	}
+
+	// Apply the migrations:
+	for version < CurrentVersion {
+		err = migrations[version](raw)
+		if err != nil {
+			return fmt.Errorf("failed to migrate from version %d to version %d: %v", version, version+1, err)
+		}
+		version++
+	}
+	raw["version"] = version
+
+	// Decode the result:
+	data, err = json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Find the unknown settings, as those are usually typos:
+	cfg.unknown = validate(raw, reflect.TypeOf(Config{}), "")
+	return nil
+}
+
+// validate checks that all the keys of the data correspond to fields of the given struct type, recursively. It
+// returns the settings that don't.
+func validate(data map[string]any, structType reflect.Type, prefix string) []unknownSetting {
+	fields := map[string]reflect.Type{}
+	var names []string
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field.Type
+		names = append(names, name)
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var problems []unknownSetting
+	for _, key := range keys {
+		fieldType, ok := fields[key]
+		if !ok {
+			problem := unknownSetting{
+				Key: prefix + key,
+			}
+			suggestion := suggest(key, names)
+			if suggestion != "" {
+				problem.Suggestion = prefix + suggestion
+			}
+			problems = append(problems, problem)
+			continue
+		}
+		problems = append(problems, validateValue(data[key], fieldType, prefix+key)...)
+	}
+	return problems
+}
+
+// validateValue checks the keys of the structs contained in the given value, which can be a struct, a pointer to a
+// struct or a slice of them.
+func validateValue(value any, valueType reflect.Type, path string) []unknownSetting {
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+	switch valueType.Kind() {
+	case reflect.Struct:
+		nested, ok := value.(map[string]any)
+		if ok {
+			return validate(nested, valueType, path+".")
+		}
+	case reflect.Slice:
+		items, ok := value.([]any)
+		if ok {
+			var problems []unknownSetting
+			for i, item := range items {
+				problems = append(problems, validateValue(item, valueType.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+			}
+			return problems
+		}
+	}
+	return nil
+}
+
+// suggest returns the name that is most similar to the given key, if it is similar enough to be a typo, or an empty
+// string otherwise.
+func suggest(key string, names []string) string {
+	result := ""
+	best := 3
+	for _, name := range names {
+		distance := levenshtein(key, name)
+		if distance < best {
+			result = name
+			best = distance
+		}
+	}
+	return result
+}
+
+// levenshtein calculates the edit distance between two strings.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
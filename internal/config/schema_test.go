@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		address string
+		err     string
+	}{
+		{
+			name:    "Without version",
+			data:    `{"address": "api.example.com:443"}`,
+			address: "api.example.com:443",
+		},
+		{
+			name:    "Current version",
+			data:    `{"version": 1, "address": "api.example.com:443"}`,
+			address: "api.example.com:443",
+		},
+		{
+			name: "Newer version",
+			data: `{"version": 2}`,
+			err:  "version 2 is newer than version 1",
+		},
+		{
+			name: "Invalid version",
+			data: `{"version": 1.5}`,
+			err:  "version should be a non negative integer",
+		},
+		{
+			name: "Unknown setting",
+			data: `{"adress": "api.example.com:443"}`,
+			err:  "unknown setting 'adress' (did you mean 'address'?)",
+		},
+		{
+			name: "Unknown nested setting",
+			data: `{"oidc": {"isuer": "https://sso.example.com"}}`,
+			err:  "unknown setting 'oidc.isuer' (did you mean 'oidc.issuer'?)",
+		},
+		{
+			name: "Unknown setting inside list",
+			data: `{"columns": {"cluster": [{"header": "X", "value": "1"}, {"header": "Y", "vaule": "2"}]}}`,
+			err:  "unknown setting 'columns.cluster[1].vaule' (did you mean 'columns.cluster[1].value'?)",
+		},
+		{
+			name:    "Valid list",
+			data:    `{"address": "a", "columns": {"cluster": [{"header": "X", "value": "1"}]}}`,
+			address: "a",
+		},
+		{
+			name:    "Aliases aren't settings",
+			data:    `{"address": "a", "aliases": {"gc": "get clusters"}}`,
+			address: "a",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &Config{}
+			err := parse([]byte(test.data), cfg)
+			if err == nil {
+				err = cfg.Check()
+			}
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing '%s', but got '%v'", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Address != test.address {
+				t.Fatalf("expected address '%s', but got '%s'", test.address, cfg.Address)
+			}
+			if cfg.Version != CurrentVersion {
+				t.Fatalf("expected version %d, but got %d", CurrentVersion, cfg.Version)
+			}
+		})
+	}
+}
+
+func TestUnsetUnknown(t *testing.T) {
+	cfg := &Config{}
+	err := parse([]byte(`{"adress": "a", "oidc": {"isuer": "b"}}`), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = cfg.Unset("adress")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = cfg.Check()
+	if err == nil || strings.Contains(err.Error(), "'adress'") || !strings.Contains(err.Error(), "'oidc.isuer'") {
+		t.Fatalf("expected error only for 'oidc.isuer', but got '%v'", err)
+	}
+	err = cfg.Unset("oidc.isuer")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = cfg.Check()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"address", "address", 0},
+		{"adress", "address", 1},
+		{"vaule", "value", 2},
+		{"kitten", "sitting", 3},
+	}
+	for _, test := range tests {
+		actual := levenshtein(test.a, test.b)
+		if actual != test.expected {
+			t.Fatalf("expected distance %d between '%s' and '%s', but got %d", test.expected, test.a, test.b, actual)
+		}
+	}
+}
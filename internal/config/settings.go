@@ -0,0 +1,214 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// protectedSettings are the settings that can't be changed with the Set and Unset methods, because they are
+// managed by the tool itself, together with the command that should be used instead.
+var protectedSettings = map[string]string{
+	"version":       "",
+	"token":         "login",
+	"refresh_token": "login",
+}
+
+// Redacted returns a copy of the configuration where the tokens and the password of the proxy have been replaced
+// with the 'REDACTED' text, so that it can be displayed or shared safely.
+func (c *Config) Redacted() *Config {
+	result := *c
+	if result.Token != "" {
+		result.Token = "REDACTED"
+	}
+	if result.RefreshToken != "" {
+		result.RefreshToken = "REDACTED"
+	}
+	if result.Proxy != "" {
+		result.Proxy = redactURL(result.Proxy)
+	}
+	return &result
+}
+
+// redactURL replaces the password contained in the given URL with the 'REDACTED' text. If the URL can't be parsed
+// and it may contain a password then all of it is replaced.
+func redactURL(text string) string {
+	parsed, err := url.Parse(text)
+	if err != nil {
+		if strings.Contains(text, "@") {
+			return "REDACTED"
+		}
+		return text
+	}
+	if parsed.User == nil {
+		return text
+	}
+	if _, ok := parsed.User.Password(); !ok {
+		return text
+	}
+	parsed.User = url.UserPassword(parsed.User.Username(), "REDACTED")
+	return parsed.String()
+}
+
+// Settings returns the names of the settings that can be changed with the Set and Unset methods, for example
+// 'address' or 'oidc.issuer', sorted alphabetically. Settings that contain lists or maps, like the columns or the
+// aliases, aren't included, as they need to be changed editing the configuration file.
+func Settings() []string {
+	var result []string
+	collectSettings("", reflect.TypeOf(Config{}), &result)
+	sort.Strings(result)
+	return result
+}
+
+func collectSettings(prefix string, typ reflect.Type, result *[]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := settingName(field)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		if _, ok := protectedSettings[key]; ok {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int:
+			*result = append(*result, key)
+		case reflect.Ptr:
+			if field.Type.Elem().Kind() == reflect.Struct {
+				collectSettings(key+".", field.Type.Elem(), result)
+			}
+		}
+	}
+}
+
+// Set changes the value of the setting with the given name, for example 'address' or 'oidc.issuer'. The value is
+// converted to the type of the setting.
+func (c *Config) Set(key, value string) error {
+	field, _, err := c.setting(key, true)
+	if err != nil {
+		return err
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("value '%s' of setting '%s' isn't a valid boolean", value, key)
+		}
+		field.SetBool(parsed)
+	case reflect.Int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("value '%s' of setting '%s' isn't a valid integer", value, key)
+		}
+		field.SetInt(int64(parsed))
+	}
+	return nil
+}
+
+// Unset restores the default value of the setting with the given name. Sections, like 'oidc', that are left without
+// any value are removed. Unknown settings of the configuration file, for example 'adress', can also be removed.
+func (c *Config) Unset(key string) error {
+	for i, setting := range c.unknown {
+		if setting.Key == key {
+			c.unknown = slices.Delete(slices.Clone(c.unknown), i, i+1)
+			return nil
+		}
+	}
+	field, parents, err := c.setting(key, false)
+	if err != nil {
+		return err
+	}
+	if !field.IsValid() {
+		return nil
+	}
+	field.Set(reflect.Zero(field.Type()))
+	for i := len(parents) - 1; i >= 0; i-- {
+		parent := parents[i]
+		if !parent.Elem().IsZero() {
+			break
+		}
+		parent.Set(reflect.Zero(parent.Type()))
+	}
+	return nil
+}
+
+// setting finds the field that corresponds to the given setting. If create is true the sections that don't exist yet
+// are created, otherwise an invalid value is returned when they don't exist. It also returns the pointers to the
+// sections that contain the field, from the outermost to the innermost.
+func (c *Config) setting(key string, create bool) (result reflect.Value, parents []reflect.Value, err error) {
+	if command, ok := protectedSettings[key]; ok {
+		if command != "" {
+			err = fmt.Errorf("setting '%s' can't be changed directly, use the '%s' command", key, command)
+		} else {
+			err = fmt.Errorf("setting '%s' can't be changed", key)
+		}
+		return
+	}
+	valid := false
+	for _, setting := range Settings() {
+		if setting == key {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		err = fmt.Errorf(
+			"setting '%s' doesn't exist or can't be changed with this command, valid settings are '%s'",
+			key, strings.Join(Settings(), "', '"),
+		)
+		return
+	}
+	current := reflect.ValueOf(c).Elem()
+	names := strings.Split(key, ".")
+	for i, name := range names {
+		for j := 0; j < current.NumField(); j++ {
+			if settingName(current.Type().Field(j)) == name {
+				result = current.Field(j)
+				break
+			}
+		}
+		if i == len(names)-1 {
+			break
+		}
+		if result.IsNil() {
+			if !create {
+				result = reflect.Value{}
+				return
+			}
+			result.Set(reflect.New(result.Type().Elem()))
+		}
+		parents = append(parents, result)
+		current = result.Elem()
+	}
+	return
+}
+
+// settingName returns the name of the setting that corresponds to the given field, which is the name used in the JSON
+// representation, or an empty string if the field isn't serialized.
+func settingName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package examples contains functions to build the examples displayed in the help of the commands.
+package examples
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Collect sets the examples of a command that groups one subcommand per type of object, like 'get', using the first
+// example of each subcommand. This way the help of the group shows how to use each of the supported types. It should
+// be called after adding the subcommands.
+func Collect(cmd *cobra.Command) {
+	var blocks []string
+	for _, subcommand := range cmd.Commands() {
+		if subcommand.Hidden || subcommand.Example == "" {
+			continue
+		}
+		first, _, _ := strings.Cut(subcommand.Example, "\n\n")
+		blocks = append(blocks, first)
+	}
+	cmd.Example = strings.Join(blocks, "\n\n")
+}
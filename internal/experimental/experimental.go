@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package experimental contains the mechanism used to hide the commands and flags that aren't stable yet. Those
+// commands and flags are hidden and rejected unless the user opts in, either setting the 'experimental.enabled'
+// option of the configuration file or the FULFILLMENT_CLI_EXPERIMENTAL environment variable.
+package experimental
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+// EnvVar is the name of the environment variable that enables the experimental features. It takes precedence over
+// the configuration file.
+const EnvVar = "FULFILLMENT_CLI_EXPERIMENTAL"
+
+// annotation is the name of the annotation used to mark commands and flags as experimental.
+const annotation = "experimental"
+
+// Enabled checks if the user opted in to use experimental features.
+func Enabled() bool {
+	value, ok := os.LookupEnv(EnvVar)
+	if ok {
+		enabled, err := strconv.ParseBool(value)
+		return err == nil && enabled
+	}
+	cfg, err := config.LoadSettings()
+	if err != nil {
+		return false
+	}
+	return cfg.Experimental != nil && cfg.Experimental.Enabled
+}
+
+// MarkCommand marks the given command as experimental.
+func MarkCommand(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[annotation] = "true"
+}
+
+// MarkFlag marks the given flag as experimental.
+func MarkFlag(flags *pflag.FlagSet, name string) {
+	flags.SetAnnotation(name, annotation, []string{"true"})
+}
+
+// Hide hides all the experimental commands and flags of the given command and its sub-commands, unless experimental
+// features are enabled.
+func Hide(root *cobra.Command) {
+	if Enabled() {
+		return
+	}
+	hide(root)
+}
+
+func hide(cmd *cobra.Command) {
+	if isCommandMarked(cmd) {
+		cmd.Hidden = true
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if isFlagMarked(flag) {
+			flag.Hidden = true
+		}
+	})
+	for _, child := range cmd.Commands() {
+		hide(child)
+	}
+}
+
+// Check checks if the given command, or any of the flags used, are experimental. If they are and experimental
+// features aren't enabled it returns an error. If they are enabled it writes a warning to the given writer.
+func Check(cmd *cobra.Command, warnings io.Writer) error {
+	var features []string
+	for current := cmd; current != nil; current = current.Parent() {
+		if isCommandMarked(current) {
+			features = append(features, fmt.Sprintf("command '%s'", current.CommandPath()))
+			break
+		}
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if isFlagMarked(flag) {
+			features = append(features, fmt.Sprintf("flag '--%s'", flag.Name))
+		}
+	})
+	if len(features) == 0 {
+		return nil
+	}
+	if !Enabled() {
+		return fmt.Errorf(
+			"%s is experimental, to use it set the environment variable '%s=true' or the "+
+				"'experimental.enabled' option in the configuration file",
+			features[0], EnvVar,
+		)
+	}
+	for _, feature := range features {
+		fmt.Fprintf(
+			warnings,
+			"Warning: %s is experimental, it may change or be removed in future versions\n",
+			feature,
+		)
+	}
+	return nil
+}
+
+func isCommandMarked(cmd *cobra.Command) bool {
+	return cmd.Annotations[annotation] == "true"
+}
+
+func isFlagMarked(flag *pflag.Flag) bool {
+	values := flag.Annotations[annotation]
+	return len(values) > 0 && values[0] == "true"
+}
@@ -0,0 +1,230 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package expressions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/innabox/fulfillment-cli/internal/output"
+)
+
+// Column is a table column whose values are calculated with a CEL expression, or copied from one of the default
+// columns of the table when the expression is nil.
+type Column struct {
+	Header     string
+	Expression *Expression
+	table      output.Column
+	index      int
+}
+
+// Definition is the definition of a column before it is compiled. When the expression is empty the column is one of
+// the default columns, selected by header. The rest of the fields are the optional settings of the column of the
+// table, and when they are zero the settings of the default column are used.
+type Definition struct {
+	Header     string
+	Expression string
+	Width      int
+	Max        int
+	Align      output.Align
+	Wide       bool
+}
+
+// Columns is a set of columns that replaces the default columns of a table. Don't create instances of this type
+// directly, use the CompileColumns function instead.
+type Columns struct {
+	defaults []output.Column
+	columns  []*Column
+}
+
+// CompileColumns parses and compiles a list of column definitions. The text is a comma separated list of definitions
+// with the format 'HEADER=EXPRESSION', or just 'HEADER' to select one of the given default columns. Commas inside
+// quotes, parentheses, brackets or braces don't separate columns, so expressions like
+// 'size(cluster.status.conditions.map(c, c.type))' can be used.
+func CompileColumns(name string, message proto.Message, text string, defaults []output.Column) (result *Columns,
+	err error) {
+	var definitions []Definition
+	for _, item := range split(text) {
+		header, expression, found := strings.Cut(item, "=")
+		header = strings.TrimSpace(header)
+		expression = strings.TrimSpace(expression)
+		if header == "" || (found && expression == "") {
+			err = fmt.Errorf(
+				"column definition '%s' isn't valid, it should have the format 'HEADER=EXPRESSION' or "+
+					"'HEADER'",
+				item,
+			)
+			return
+		}
+		definitions = append(definitions, Definition{
+			Header:     header,
+			Expression: expression,
+		})
+	}
+	result, err = CompileDefinitions(name, message, definitions, defaults)
+	return
+}
+
+// CompileDefinitions compiles a list of column definitions. It is like CompileColumns, but the definitions are already
+// separated, for example because they were loaded from a file.
+func CompileDefinitions(name string, message proto.Message, definitions []Definition,
+	defaults []output.Column) (result *Columns, err error) {
+	var columns []*Column
+	for _, definition := range definitions {
+		column := &Column{
+			Header: definition.Header,
+			index:  -1,
+		}
+		if definition.Expression != "" {
+			column.Expression, err = Compile(name, message, definition.Expression)
+			if err != nil {
+				return
+			}
+			column.table = output.Column{
+				Header: definition.Header,
+			}
+		} else {
+			column.index = output.FindColumn(defaults, definition.Header)
+			if column.index == -1 {
+				err = fmt.Errorf(
+					"column '%s' doesn't exist, valid columns are %s",
+					definition.Header, quoteHeaders(defaults),
+				)
+				return
+			}
+			column.table = defaults[column.index]
+		}
+		if definition.Width != 0 {
+			column.table.Width = definition.Width
+		}
+		if definition.Max != 0 {
+			column.table.Max = definition.Max
+		}
+		if definition.Align != output.AlignLeft {
+			column.table.Align = definition.Align
+		}
+		column.table.Wide = definition.Wide
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		err = fmt.Errorf("at least one column definition is required")
+		return
+	}
+	result = &Columns{
+		defaults: defaults,
+		columns:  columns,
+	}
+	return
+}
+
+// Table returns the columns that should be used to create the table.
+func (c *Columns) Table() []output.Column {
+	result := make([]output.Column, len(c.columns))
+	for i, column := range c.columns {
+		result[i] = column.table
+	}
+	return result
+}
+
+// Row calculates the values of the columns for the given object. The values of the default columns are needed for the
+// columns that are copied from them.
+func (c *Columns) Row(object proto.Message, defaults []string) (result []string, err error) {
+	result = make([]string, len(c.columns))
+	for i, column := range c.columns {
+		if column.Expression == nil {
+			if column.index < len(defaults) {
+				result[i] = defaults[column.index]
+			}
+			continue
+		}
+		result[i], err = column.Expression.Text(object)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Cells is like Row, but it also returns the raw values and types of the columns calculated with CEL expressions.
+func (c *Columns) Cells(object proto.Message, defaults []string) (result []output.Cell, err error) {
+	result = make([]output.Cell, len(c.columns))
+	for i, column := range c.columns {
+		if column.Expression == nil {
+			if column.index < len(defaults) {
+				result[i] = output.Cell{
+					Text:  defaults[column.index],
+					Value: defaults[column.index],
+					Type:  "string",
+				}
+			}
+			continue
+		}
+		var value ref.Val
+		value, err = column.Expression.Evaluate(object)
+		if err != nil {
+			return
+		}
+		cell := output.Cell{
+			Type: value.Type().TypeName(),
+		}
+		cell.Text, err = Text(value)
+		if err == nil {
+			cell.Value, err = Native(value)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to convert result of expression '%s': %v", column.Expression.text, err)
+			return
+		}
+		result[i] = cell
+	}
+	return
+}
+
+func quoteHeaders(columns []output.Column) string {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = fmt.Sprintf("'%s'", column.Header)
+	}
+	return strings.Join(headers, ", ")
+}
+
+// split splits the text by the commas that aren't inside quotes, parentheses, brackets or braces.
+func split(text string) []string {
+	var result []string
+	depth := 0
+	var quote rune
+	start := 0
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			result = append(result, text[start:i])
+			start = i + 1
+		}
+	}
+	result = append(result, text[start:])
+	return result
+}
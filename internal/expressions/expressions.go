@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package expressions contains functions to evaluate CEL expressions against API objects.
+package expressions
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Expression is a compiled CEL expression that can be evaluated against objects of one type.
+type Expression struct {
+	name    string
+	text    string
+	program cel.Program
+}
+
+// Compile compiles the given CEL expression. The expression can use a variable with the given name whose type is the
+// type of the given message, for example 'cluster.status.api_url', the standard CEL extension libraries and the
+// helper functions 'age', 'since' and 'humanize'.
+func Compile(name string, message proto.Message, text string) (result *Expression, err error) {
+	typeName := string(message.ProtoReflect().Descriptor().FullName())
+	options := []cel.EnvOption{
+		cel.Types(message),
+		cel.Variable(name, cel.ObjectType(typeName)),
+	}
+	options = append(options, functions()...)
+	env, err := cel.NewEnv(options...)
+	if err != nil {
+		err = fmt.Errorf("failed to create CEL environment: %v", err)
+		return
+	}
+	ast, issues := env.Compile(text)
+	if issues != nil && issues.Err() != nil {
+		err = fmt.Errorf("failed to compile expression '%s': %v", text, issues.Err())
+		return
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		err = fmt.Errorf("failed to create program for expression '%s': %v", text, err)
+		return
+	}
+	result = &Expression{
+		name:    name,
+		text:    text,
+		program: program,
+	}
+	return
+}
+
+// Evaluate evaluates the expression for the given object.
+func (e *Expression) Evaluate(object proto.Message) (result ref.Val, err error) {
+	result, _, err = e.program.Eval(map[string]any{
+		e.name: object,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to evaluate expression '%s': %v", e.text, err)
+	}
+	return
+}
+
+// Text evaluates the expression for the given object and converts the result to text suitable for displaying it to
+// the user. Strings are returned without quotes, null values are returned as a dash and other values are returned
+// using their JSON representation.
+func (e *Expression) Text(object proto.Message) (result string, err error) {
+	value, err := e.Evaluate(object)
+	if err != nil {
+		return
+	}
+	result, err = Text(value)
+	if err != nil {
+		err = fmt.Errorf("failed to convert result of expression '%s' to text: %v", e.text, err)
+	}
+	return
+}
+
+// Text converts the given CEL value to text suitable for displaying it to the user.
+func Text(value ref.Val) (result string, err error) {
+	switch typed := value.(type) {
+	case types.String:
+		result = string(typed)
+		return
+	case types.Null:
+		result = "-"
+		return
+	}
+	native, err := value.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return
+	}
+	json := native.(*structpb.Value)
+	switch json.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		result = json.GetStringValue()
+	case *structpb.Value_NullValue:
+		result = "-"
+	default:
+		var data []byte
+		data, err = protojson.Marshal(json)
+		if err != nil {
+			return
+		}
+		result = string(data)
+	}
+	return
+}
+
+// Native converts the given CEL value to its generic JSON representation, using maps, slices, strings, numbers and
+// booleans. Null values are converted to nil.
+func Native(value ref.Val) (result any, err error) {
+	if _, ok := value.(types.Null); ok {
+		return
+	}
+	native, err := value.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return
+	}
+	result = native.(*structpb.Value).AsInterface()
+	return
+}
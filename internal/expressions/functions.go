@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package expressions
+
+import (
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+
+	"github.com/innabox/fulfillment-cli/internal/output"
+)
+
+// functions returns the options that add to the CEL environment the standard extension libraries for strings, math,
+// encoders, lists and sets, for example 'cluster.id.upperAscii()' or 'math.greatest(1, 2)', and the helper functions
+// that are useful in table definitions:
+//
+//   - age(timestamp) returns the time elapsed since the timestamp in a short format, for example '3d2h'.
+//   - since(timestamp) returns the time elapsed since the timestamp as a duration.
+//   - humanize(duration) returns the duration in a short format, for example '5h10m'.
+//
+// Timestamps that aren't set are represented by age with a dash.
+func functions() []cel.EnvOption {
+	return []cel.EnvOption{
+		ext.Strings(),
+		ext.Math(),
+		ext.Encoders(),
+		ext.Lists(),
+		ext.Sets(),
+		cel.Function(
+			"age",
+			cel.Overload(
+				"age_timestamp",
+				[]*cel.Type{cel.TimestampType},
+				cel.StringType,
+				cel.UnaryBinding(func(value ref.Val) ref.Val {
+					timestamp, ok := value.(types.Timestamp)
+					if !ok {
+						return types.MaybeNoSuchOverloadErr(value)
+					}
+					if timestamp.Unix() == 0 {
+						return types.String("-")
+					}
+					return types.String(output.Duration(time.Since(timestamp.Time)))
+				}),
+			),
+		),
+		cel.Function(
+			"since",
+			cel.Overload(
+				"since_timestamp",
+				[]*cel.Type{cel.TimestampType},
+				cel.DurationType,
+				cel.UnaryBinding(func(value ref.Val) ref.Val {
+					timestamp, ok := value.(types.Timestamp)
+					if !ok {
+						return types.MaybeNoSuchOverloadErr(value)
+					}
+					return types.Duration{Duration: time.Since(timestamp.Time)}
+				}),
+			),
+		),
+		cel.Function(
+			"humanize",
+			cel.Overload(
+				"humanize_duration",
+				[]*cel.Type{cel.DurationType},
+				cel.StringType,
+				cel.UnaryBinding(func(value ref.Val) ref.Val {
+					duration, ok := value.(types.Duration)
+					if !ok {
+						return types.MaybeNoSuchOverloadErr(value)
+					}
+					return types.String(output.Duration(duration.Duration))
+				}),
+			),
+		),
+	}
+}
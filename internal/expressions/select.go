@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package expressions
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/common/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Select returns the objects for which the expression is true. The expression must return a boolean for all the
+// objects, otherwise an error is returned.
+func Select[T proto.Message](expression *Expression, objects []T) (result []T, err error) {
+	for _, object := range objects {
+		value, err := expression.Evaluate(object)
+		if err != nil {
+			return nil, err
+		}
+		selected, ok := value.(types.Bool)
+		if !ok {
+			return nil, fmt.Errorf(
+				"expression '%s' should return a boolean, but it returned a value of type '%s'",
+				expression.text, value.Type().TypeName(),
+			)
+		}
+		if selected {
+			result = append(result, object)
+		}
+	}
+	return
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package expressions
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"google.golang.org/protobuf/proto"
+)
+
+// Sort sorts the given objects according to the values of the expression, in ascending order, or in descending order
+// if reverse is true. The sort is stable, so objects with equal values keep the order they had. Numbers are compared
+// as numbers, even if they have different types, strings are compared lexicographically and null values are always
+// placed at the end.
+func Sort[T proto.Message](expression *Expression, objects []T, reverse bool) error {
+	type item struct {
+		object T
+		key    ref.Val
+	}
+	items := make([]item, len(objects))
+	for i, object := range objects {
+		key, err := expression.Evaluate(object)
+		if err != nil {
+			return err
+		}
+		items[i] = item{
+			object: object,
+			key:    key,
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return compare(items[i].key, items[j].key, reverse) < 0
+	})
+	for i, item := range items {
+		objects[i] = item.object
+	}
+	return nil
+}
+
+// compare compares two values returned by an expression. The result is negative if a goes before b, zero if they are
+// equivalent and positive if a goes after b.
+func compare(a, b ref.Val, reverse bool) int {
+	// Null values go always at the end, regardless of the direction:
+	aNull := a.Type() == types.NullType
+	bNull := b.Type() == types.NullType
+	switch {
+	case aNull && bNull:
+		return 0
+	case aNull:
+		return 1
+	case bNull:
+		return -1
+	}
+
+	// Use the comparison provided by CEL when possible, and otherwise compare the names of the types first and then
+	// the text representations, so that the result is at least deterministic:
+	result := 0
+	comparer, ok := a.(traits.Comparer)
+	if ok {
+		value, ok := comparer.Compare(b).(types.Int)
+		if ok {
+			result = int(value)
+		} else {
+			result = compareText(a, b)
+		}
+	} else {
+		result = compareText(a, b)
+	}
+	if reverse {
+		result = -result
+	}
+	return result
+}
+
+// compareText compares two values that CEL can't compare, using the names of their types and their text
+// representations.
+func compareText(a, b ref.Val) int {
+	result := strings.Compare(a.Type().TypeName(), b.Type().TypeName())
+	if result != 0 {
+		return result
+	}
+	aText, _ := Text(a)
+	bText, _ := Text(b)
+	return strings.Compare(aText, bText)
+}
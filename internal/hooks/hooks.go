@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package hooks contains the functions that run the commands that the user configured in the 'hooks' section of the
+// configuration file before and after the operations that modify objects.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+)
+
+// Operations that support hooks:
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Event describes the operation that is about to be executed, or that has just been executed. It is passed to the hook
+// command in JSON format in the standard input, and some of the fields also in environment variables.
+type Event struct {
+	// Operation is the name of the operation, for example 'delete'.
+	Operation string
+
+	// Type is the type of the object, for example 'clusterorder'.
+	Type string
+
+	// ID is the identifier of the object. It is empty for the pre create hook, as the identifier is assigned by the
+	// server.
+	ID string
+
+	// Object is the object that will be created or updated, or the object that was created, updated or that will
+	// be deleted. It may be nil.
+	Object proto.Message
+}
+
+// Names of the environment variables passed to the hook commands:
+const (
+	hookEnvVar      = "FULFILLMENT_CLI_HOOK"
+	operationEnvVar = "FULFILLMENT_CLI_OPERATION"
+	typeEnvVar      = "FULFILLMENT_CLI_TYPE"
+	idEnvVar        = "FULFILLMENT_CLI_ID"
+)
+
+// marshalOptions are the options used to convert the object to JSON. Note that we use the names of the fields as they
+// appear in the protocol buffers specification, like the rest of the tool.
+var marshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
+
+// Pre runs the hook configured to run before the operation, if any. If the hook fails the returned error explains why,
+// and the operation should not be executed.
+func Pre(ctx context.Context, cfg *config.Config, event *Event) error {
+	return run(ctx, cfg, "pre_"+event.Operation, event)
+}
+
+// Post runs the hook configured to run after the operation, if any. As the operation was already executed a failure
+// of the hook is only reported as a warning.
+func Post(ctx context.Context, cfg *config.Config, event *Event) {
+	err := run(ctx, cfg, "post_"+event.Operation, event)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// run runs the hook with the given name, if it is configured.
+func run(ctx context.Context, cfg *config.Config, name string, event *Event) error {
+	command := lookup(cfg, name)
+	if command == "" {
+		return nil
+	}
+
+	// Prepare the description of the operation that is passed in the standard input:
+	input := map[string]any{
+		"hook":      name,
+		"operation": event.Operation,
+		"type":      event.Type,
+	}
+	if event.ID != "" {
+		input["id"] = event.ID
+	}
+	if event.Object != nil {
+		data, err := marshalOptions.Marshal(event.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal object for hook '%s': %v", name, err)
+		}
+		input["object"] = json.RawMessage(data)
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input for hook '%s': %v", name, err)
+	}
+
+	// Run the command. Note that its output goes to the standard error, so that it doesn't get mixed with the
+	// output of the tool, which may be processed by other tools.
+	hook := exec.CommandContext(ctx, "sh", "-c", command)
+	hook.Stdin = bytes.NewReader(data)
+	hook.Stdout = os.Stderr
+	hook.Stderr = os.Stderr
+	hook.Env = append(
+		os.Environ(),
+		hookEnvVar+"="+name,
+		operationEnvVar+"="+event.Operation,
+		typeEnvVar+"="+event.Type,
+		idEnvVar+"="+event.ID,
+	)
+	err = hook.Run()
+	if err != nil {
+		return fmt.Errorf("hook '%s' failed: %v", name, err)
+	}
+	return nil
+}
+
+// lookup returns the command configured for the hook with the given name, or an empty string if there is none.
+func lookup(cfg *config.Config, name string) string {
+	hooks := cfg.Hooks
+	if hooks == nil {
+		return ""
+	}
+	switch name {
+	case "pre_create":
+		return hooks.PreCreate
+	case "post_create":
+		return hooks.PostCreate
+	case "pre_update":
+		return hooks.PreUpdate
+	case "post_update":
+		return hooks.PostUpdate
+	case "pre_delete":
+		return hooks.PreDelete
+	case "post_delete":
+		return hooks.PostDelete
+	default:
+		return ""
+	}
+}
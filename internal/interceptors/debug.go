@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Debug levels, as used by the '--debug-grpc' command line flag:
+const (
+	// DebugBasic logs the method, sizes, latency and status code of each call.
+	DebugBasic = "basic"
+
+	// DebugFull additionally logs the metadata and the payloads of the calls.
+	DebugFull = "full"
+)
+
+// DebugLevels are the valid debug levels.
+var DebugLevels = []string{DebugBasic, DebugFull}
+
+// redactedKeys are the metadata keys whose values are replaced with 'REDACTED' in the debug log.
+var redactedKeys = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"set-cookie":    true,
+}
+
+// debugMarshalOptions are the options used to render the payloads in the debug log, in one line so that each entry
+// of the log is also one line.
+var debugMarshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
+
+// Debug creates an interceptor that writes to the given writer a line for each call, with the method, the sizes of
+// the request and the response, the latency and the status code. When the level is 'full' it also writes the
+// metadata and the payloads. It is intended to be the last interceptor of the chain, so that each attempt that
+// actually goes to the wire is logged, including retries.
+func Debug(writer io.Writer, level string) grpc.UnaryClientInterceptor {
+	logger := &debugLogger{
+		writer: writer,
+		full:   level == DebugFull,
+	}
+	return func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		logger.metadata(method, "request metadata", outgoingMetadata(ctx))
+		logger.payload(method, "request", request)
+		var header, trailer metadata.MD
+		opts = append(opts, grpc.Header(&header), grpc.Trailer(&trailer))
+		start := time.Now()
+		err := invoker(ctx, method, request, response, conn, opts...)
+		elapsed := time.Since(start)
+		logger.metadata(method, "response header", header)
+		if err == nil {
+			logger.payload(method, "response", response)
+		}
+		logger.metadata(method, "response trailer", trailer)
+		logger.printf(
+			"%s: %s in %s, sent %d bytes, received %d bytes",
+			method, status.Code(err), elapsed.Round(time.Microsecond), messageSize(request),
+			responseSize(response, err),
+		)
+		return err
+	}
+}
+
+// StreamDebug is like Debug, but for streaming calls. A line is written for each message sent or received, and
+// another when the stream ends.
+func StreamDebug(writer io.Writer, level string) grpc.StreamClientInterceptor {
+	logger := &debugLogger{
+		writer: writer,
+		full:   level == DebugFull,
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		logger.metadata(method, "request metadata", outgoingMetadata(ctx))
+		start := time.Now()
+		stream, err := streamer(ctx, desc, conn, method, opts...)
+		if err != nil {
+			logger.printf("%s: %s in %s", method, status.Code(err), time.Since(start).Round(time.Microsecond))
+			return nil, err
+		}
+		return &debugStream{
+			ClientStream: stream,
+			logger:       logger,
+			method:       method,
+			start:        start,
+		}, nil
+	}
+}
+
+type debugStream struct {
+	grpc.ClientStream
+	logger *debugLogger
+	method string
+	start  time.Time
+	header sync.Once
+	end    sync.Once
+}
+
+func (s *debugStream) SendMsg(message any) error {
+	s.logger.payload(s.method, "sent", message)
+	err := s.ClientStream.SendMsg(message)
+	if err == nil {
+		s.logger.printf("%s: sent %d bytes", s.method, messageSize(message))
+	}
+	return err
+}
+
+func (s *debugStream) RecvMsg(message any) error {
+	err := s.ClientStream.RecvMsg(message)
+	s.header.Do(func() {
+		header, headerErr := s.ClientStream.Header()
+		if headerErr == nil {
+			s.logger.metadata(s.method, "response header", header)
+		}
+	})
+	if err == nil {
+		s.logger.payload(s.method, "received", message)
+		s.logger.printf("%s: received %d bytes", s.method, messageSize(message))
+		return nil
+	}
+	s.end.Do(func() {
+		s.logger.metadata(s.method, "response trailer", s.ClientStream.Trailer())
+		code := status.Code(err)
+		if errors.Is(err, io.EOF) {
+			code = status.Code(nil)
+		}
+		s.logger.printf("%s: %s after %s", s.method, code, time.Since(s.start).Round(time.Microsecond))
+	})
+	return err
+}
+
+// debugLogger writes the lines of the debug log. The lock ensures that lines written by concurrent calls aren't mixed.
+type debugLogger struct {
+	writer io.Writer
+	full   bool
+	lock   sync.Mutex
+}
+
+func (l *debugLogger) printf(format string, args ...any) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	fmt.Fprintf(l.writer, "gRPC "+format+"\n", args...)
+}
+
+// metadata writes the given metadata, with the sensitive values redacted, if the level is 'full'.
+func (l *debugLogger) metadata(method, what string, md metadata.MD) {
+	if !l.full || len(md) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(md))
+	for key := range md {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, key := range keys {
+		for _, value := range md[key] {
+			if redactedKeys[strings.ToLower(key)] {
+				value = "REDACTED"
+			}
+			pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	l.printf("%s: %s: %s", method, what, strings.Join(pairs, " "))
+}
+
+// payload writes the JSON representation of the message if the level is 'full'.
+func (l *debugLogger) payload(method, what string, message any) {
+	if !l.full {
+		return
+	}
+	typed, ok := message.(proto.Message)
+	if !ok {
+		return
+	}
+	data, err := debugMarshalOptions.Marshal(typed)
+	if err != nil {
+		l.printf("%s: %s: failed to render: %v", method, what, err)
+		return
+	}
+	l.printf("%s: %s: %s", method, what, data)
+}
+
+func outgoingMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return md
+}
+
+func messageSize(message any) int {
+	typed, ok := message.(proto.Message)
+	if !ok {
+		return 0
+	}
+	return proto.Size(typed)
+}
+
+func responseSize(response any, err error) int {
+	if err != nil {
+		return 0
+	}
+	return messageSize(response)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultFilters creates an interceptor that adds filters to the list requests. The keys of the map are the full
+// names of the methods, for example '/fulfillment.v1.Clusters/List', and the values are the filters. When the request
+// already contains a filter both are combined, so that only the objects that match both are returned.
+func DefaultFilters(filters map[string]string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		filter := filters[method]
+		message, ok := request.(proto.Message)
+		if filter == "" || !ok {
+			return invoker(ctx, method, request, response, conn, opts...)
+		}
+		field := message.ProtoReflect().Descriptor().Fields().ByName("filter")
+		if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+			return invoker(ctx, method, request, response, conn, opts...)
+		}
+
+		// Modify a copy of the request, as the caller may reuse the original:
+		message = proto.Clone(message)
+		reflected := message.ProtoReflect()
+		current := reflected.Get(field).String()
+		if current != "" {
+			filter = "(" + filter + ") and (" + current + ")"
+		}
+		reflected.Set(field, protoreflect.ValueOfString(filter))
+		return invoker(ctx, method, message, response, conn, opts...)
+	}
+}
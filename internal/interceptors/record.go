@@ -0,0 +1,431 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package interceptors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordedCall is the content of each of the files written by the Record interceptors. For unary calls there is one
+// response, unless the call failed. For streaming calls there is one response for each message received. The status
+// is present when the call finished with an error.
+type recordedCall struct {
+	Method    string            `json:"method"`
+	Request   json.RawMessage   `json:"request,omitempty"`
+	Responses []json.RawMessage `json:"responses,omitempty"`
+	Status    json.RawMessage   `json:"status,omitempty"`
+}
+
+// recordMarshalOptions are the options used to write the requests, responses and status of the recorded calls.
+var recordMarshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
+
+// recorder writes the recorded calls to files in a directory. The files are numbered, so that they can be replayed in
+// the same order, and the numbers continue after the files already in the directory, so that the calls of several
+// commands can be recorded in the same directory.
+type recorder struct {
+	dir  string
+	lock sync.Mutex
+}
+
+// Record creates an interceptor that writes each unary call, with its request and its response or error, to a JSON
+// file in the given directory, so that it can later be replayed with the Replay interceptor. It is intended to be the
+// last interceptor of the chain, so that what is recorded is what is actually sent to the server.
+func Record(dir string) grpc.UnaryClientInterceptor {
+	recorder := &recorder{
+		dir: dir,
+	}
+	return func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, request, response, conn, opts...)
+		call := &recordedCall{
+			Method: method,
+		}
+		call.Request = recordMessage(request)
+		if err == nil {
+			call.Responses = append(call.Responses, recordMessage(response))
+		} else {
+			call.Status = recordStatus(err)
+		}
+		recorder.save(call)
+		return err
+	}
+}
+
+// StreamRecord is like Record, but for streaming calls. The file is written again each time that a message is sent or
+// received, so that the messages aren't lost if the process is interrupted while the stream is still open. Till the
+// stream ends the status of the call is 'Canceled', and then the replayed stream waits for the context to be
+// cancelled after returning all the messages, like the original one.
+func StreamRecord(dir string) grpc.StreamClientInterceptor {
+	recorder := &recorder{
+		dir: dir,
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, conn, method, opts...)
+		if err != nil {
+			recorder.save(&recordedCall{
+				Method: method,
+				Status: recordStatus(err),
+			})
+			return nil, err
+		}
+		result := &recordStream{
+			ClientStream: stream,
+			recorder:     recorder,
+			call: &recordedCall{
+				Method: method,
+				Status: recordStatus(status.Error(codes.Canceled, "stream wasn't finished")),
+			},
+		}
+		result.file = recorder.reserve(method)
+		recorder.write(result.file, result.call)
+		return result, nil
+	}
+}
+
+type recordStream struct {
+	grpc.ClientStream
+	recorder *recorder
+	call     *recordedCall
+	file     string
+	end      sync.Once
+}
+
+func (s *recordStream) SendMsg(message any) error {
+	s.call.Request = recordMessage(message)
+	s.recorder.write(s.file, s.call)
+	return s.ClientStream.SendMsg(message)
+}
+
+func (s *recordStream) RecvMsg(message any) error {
+	err := s.ClientStream.RecvMsg(message)
+	if err == nil {
+		s.call.Responses = append(s.call.Responses, recordMessage(message))
+		s.recorder.write(s.file, s.call)
+		return nil
+	}
+	s.end.Do(func() {
+		s.call.Status = nil
+		if !errors.Is(err, io.EOF) {
+			s.call.Status = recordStatus(err)
+		}
+		s.recorder.write(s.file, s.call)
+	})
+	return err
+}
+
+// save writes the call to the next numbered file of the directory.
+func (r *recorder) save(call *recordedCall) {
+	r.write(r.reserve(call.Method), call)
+}
+
+// reserve creates an empty file for the given method, with the next number, and returns its name. It returns an empty
+// string if the file can't be created. Failing to record calls shouldn't make them fail, so errors are only reported
+// as warnings.
+func (r *recorder) reserve(method string) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err := os.MkdirAll(r.dir, 0755)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create recording directory '%s': %v\n", r.dir, err)
+		return ""
+	}
+	files, err := recordedFiles(r.dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return ""
+	}
+	next := 1
+	if len(files) > 0 {
+		last := filepath.Base(files[len(files)-1])
+		number, _, _ := strings.Cut(last, "-")
+		value, err := strconv.Atoi(number)
+		if err == nil {
+			next = value + 1
+		}
+	}
+	name := strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", ".")
+	file := filepath.Join(r.dir, fmt.Sprintf("%06d-%s.json", next, name))
+	err = os.WriteFile(file, nil, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create file '%s': %v\n", file, err)
+		return ""
+	}
+	return file
+}
+
+// write replaces the content of the given file with the call. It does nothing if the file name is empty, as that
+// means that the file couldn't be reserved.
+func (r *recorder) write(file string, call *recordedCall) {
+	if file == "" {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	data, err := json.MarshalIndent(call, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to marshal recorded call to '%s': %v\n", call.Method, err)
+		return
+	}
+	err = os.WriteFile(file, append(data, '\n'), 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write recorded call to '%s': %v\n", file, err)
+	}
+}
+
+// Replay creates an interceptor that answers the unary calls with the responses recorded by the Record interceptor
+// in the given directory, without sending anything to the server. Each call is answered with the first recorded call
+// that has the same method and request and that hasn't been used yet. Calls that weren't recorded fail with the
+// 'FailedPrecondition' code.
+func Replay(dir string) grpc.UnaryClientInterceptor {
+	player := &player{
+		dir: dir,
+	}
+	return func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		call, err := player.find(method, request)
+		if err != nil {
+			return err
+		}
+		if call.Status != nil {
+			return replayStatus(call.Status)
+		}
+		if len(call.Responses) == 0 {
+			return status.Errorf(codes.Internal, "recorded call to '%s' has no response", method)
+		}
+		return replayMessage(call.Responses[0], response)
+	}
+}
+
+// StreamReplay is like Replay, but for streaming calls. The recorded messages are returned one after the other, and
+// then the recorded status. If the recorded stream was cancelled, for example because the user interrupted the
+// command, the replayed stream waits till the context is cancelled as well.
+func StreamReplay(dir string) grpc.StreamClientInterceptor {
+	player := &player{
+		dir: dir,
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &replayStream{
+			ctx:    ctx,
+			player: player,
+			method: method,
+		}, nil
+	}
+}
+
+type replayStream struct {
+	ctx     context.Context
+	player  *player
+	method  string
+	request any
+	call    *recordedCall
+	next    int
+}
+
+func (s *replayStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *replayStream) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (s *replayStream) CloseSend() error {
+	return nil
+}
+
+func (s *replayStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *replayStream) SendMsg(message any) error {
+	s.request = message
+	return nil
+}
+
+func (s *replayStream) RecvMsg(message any) error {
+	if s.call == nil {
+		call, err := s.player.find(s.method, s.request)
+		if err != nil {
+			return err
+		}
+		s.call = call
+	}
+	if s.next < len(s.call.Responses) {
+		err := replayMessage(s.call.Responses[s.next], message)
+		if err != nil {
+			return err
+		}
+		s.next++
+		return nil
+	}
+	if s.call.Status == nil {
+		return io.EOF
+	}
+	err := replayStatus(s.call.Status)
+	code := status.Code(err)
+	if code == codes.Canceled || code == codes.DeadlineExceeded {
+		<-s.ctx.Done()
+		return status.FromContextError(s.ctx.Err()).Err()
+	}
+	return err
+}
+
+// player finds the recorded calls that match the calls made by the replay interceptors. The files are loaded the first
+// time that a call is made.
+type player struct {
+	dir    string
+	lock   sync.Mutex
+	once   sync.Once
+	calls  []*recordedCall
+	used   []bool
+	loaded error
+}
+
+func (p *player) find(method string, request any) (result *recordedCall, err error) {
+	p.once.Do(p.load)
+	if p.loaded != nil {
+		err = p.loaded
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, call := range p.calls {
+		if p.used[i] || call.Method != method || !sameRequest(call.Request, request) {
+			continue
+		}
+		p.used[i] = true
+		result = call
+		return
+	}
+	err = status.Errorf(
+		codes.FailedPrecondition,
+		"there is no recorded call to '%s' with request %s in directory '%s'",
+		method, recordMessage(request), p.dir,
+	)
+	return
+}
+
+func (p *player) load() {
+	files, err := recordedFiles(p.dir)
+	if err != nil {
+		p.loaded = err
+		return
+	}
+	if len(files) == 0 {
+		p.loaded = fmt.Errorf("there are no recorded calls in directory '%s'", p.dir)
+		return
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			p.loaded = fmt.Errorf("failed to read recorded call '%s': %v", file, err)
+			return
+		}
+		call := &recordedCall{}
+		err = json.Unmarshal(data, call)
+		if err != nil {
+			p.loaded = fmt.Errorf("failed to parse recorded call '%s': %v", file, err)
+			return
+		}
+		p.calls = append(p.calls, call)
+	}
+	p.used = make([]bool, len(p.calls))
+}
+
+// recordedFiles returns the names of the recorded calls of the given directory, sorted by number. It returns an empty
+// list if the directory doesn't exist.
+func recordedFiles(dir string) (result []string, err error) {
+	result, err = filepath.Glob(filepath.Join(dir, "[0-9]*-*.json"))
+	if err != nil {
+		err = fmt.Errorf("failed to list recorded calls in directory '%s': %v", dir, err)
+		return
+	}
+	sort.Strings(result)
+	return
+}
+
+// sameRequest checks if the recorded request is equal to the one of the call.
+func sameRequest(recorded json.RawMessage, request any) bool {
+	typed, ok := request.(proto.Message)
+	if !ok {
+		return recorded == nil
+	}
+	if recorded == nil {
+		return proto.Size(typed) == 0
+	}
+	message := typed.ProtoReflect().New().Interface()
+	err := protojson.Unmarshal(recorded, message)
+	if err != nil {
+		return false
+	}
+	return proto.Equal(message, typed)
+}
+
+func recordMessage(message any) json.RawMessage {
+	typed, ok := message.(proto.Message)
+	if !ok {
+		return nil
+	}
+	data, err := recordMarshalOptions.Marshal(typed)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func recordStatus(err error) json.RawMessage {
+	return recordMessage(status.Convert(err).Proto())
+}
+
+func replayMessage(data json.RawMessage, message any) error {
+	typed, ok := message.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "can't replay response of type '%T'", message)
+	}
+	err := protojson.Unmarshal(data, typed)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to parse recorded response: %v", err)
+	}
+	return nil
+}
+
+func replayStatus(data json.RawMessage) error {
+	recorded := status.New(codes.Unknown, "").Proto()
+	err := protojson.Unmarshal(data, recorded)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to parse recorded status: %v", err)
+	}
+	return status.FromProto(recorded).Err()
+}
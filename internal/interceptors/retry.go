@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Retry creates an interceptor that sets a deadline for each attempt of a call, and that retries the idempotent calls
+// that fail because the server is unavailable. The delay between retries starts with the given backoff and is doubled
+// after each attempt. A message is written to the given writer before each retry, so that the user knows why the
+// command is slower. A zero timeout means that there is no deadline, and zero retries disables retrying.
+func Retry(timeout time.Duration, retries int, backoff time.Duration, writer io.Writer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		idempotent := idempotentMethods[path.Base(method)]
+		delay := backoff
+		for attempt := 0; ; attempt++ {
+			err := invokeWithTimeout(ctx, timeout, method, request, response, conn, invoker, opts...)
+			if err == nil || ctx.Err() != nil || !idempotent || attempt >= retries {
+				return err
+			}
+			if status.Code(err) != codes.Unavailable && status.Code(err) != codes.DeadlineExceeded {
+				return err
+			}
+			fmt.Fprintf(writer, "%s (retrying in %s)\n", retryReason(status.Code(err), timeout), delay)
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			delay *= 2
+		}
+	}
+}
+
+// retryReason returns the message that explains to the user why a call is retried, according to the status code of
+// the failed attempt.
+func retryReason(code codes.Code, timeout time.Duration) string {
+	switch {
+	case code == codes.DeadlineExceeded && timeout > 0:
+		return fmt.Sprintf("Call to the server timed out after %s", timeout)
+	case code == codes.DeadlineExceeded:
+		return "Call to the server timed out"
+	default:
+		return "Server is unavailable"
+	}
+}
+
+// invokeWithTimeout invokes the call with a deadline, unless the timeout is zero.
+func invokeWithTimeout(ctx context.Context, timeout time.Duration, method string, request, response any,
+	conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, request, response, conn, opts...)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package interceptors contains the gRPC client interceptors used by the connections to the server.
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxThrottlingRetries is the maximum number of times that a call will be retried when the server asks to slow down.
+const maxThrottlingRetries = 3
+
+// maxThrottlingDelay is the maximum delay that will be honored. If the server asks to wait longer than this the error
+// is returned to the caller instead.
+const maxThrottlingDelay = time.Minute
+
+// idempotentMethods contains the names of the methods that can be safely retried. The 'Update' method isn't included
+// even if it only sends the fields in the update mask: when an attempt fails the update may still have been applied,
+// and retrying it could overwrite the changes that controllers made to those fields in the meantime.
+var idempotentMethods = map[string]bool{
+	"Get":           true,
+	"GetKubeconfig": true,
+	"List":          true,
+}
+
+// Throttling creates an interceptor that handles the 'RESOURCE_EXHAUSTED' errors that contain retry information. For
+// idempotent calls it waits the time requested by the server and tries again, writing a message to the given writer
+// so that the user knows why the command is slower. For other calls it adds the requested delay to the error message.
+func Throttling(writer io.Writer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		idempotent := idempotentMethods[path.Base(method)]
+		for attempt := 0; ; attempt++ {
+			err := invoker(ctx, method, request, response, conn, opts...)
+			delay, ok := retryDelay(err)
+			if !ok {
+				return err
+			}
+			if !idempotent || attempt >= maxThrottlingRetries || delay > maxThrottlingDelay {
+				return fmt.Errorf("server asked us to slow down, try again in %s: %w", seconds(delay), err)
+			}
+			fmt.Fprintf(writer, "Server asked us to slow down (retrying in %s)\n", seconds(delay))
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// retryDelay checks if the error is a 'RESOURCE_EXHAUSTED' error with retry information, and returns the delay
+// requested by the server.
+func retryDelay(err error) (result time.Duration, ok bool) {
+	if err == nil {
+		return
+	}
+	grpcStatus, isStatus := status.FromError(err)
+	if !isStatus || grpcStatus.Code() != codes.ResourceExhausted {
+		return
+	}
+	for _, detail := range grpcStatus.Details() {
+		info, isInfo := detail.(*errdetails.RetryInfo)
+		if isInfo && info.RetryDelay != nil {
+			result = info.RetryDelay.AsDuration()
+			ok = true
+			return
+		}
+	}
+	return
+}
+
+// seconds formats the delay as a whole number of seconds, rounding up, as that is what users expect to see.
+func seconds(delay time.Duration) string {
+	return fmt.Sprintf("%ds", int((delay+time.Second-1)/time.Second))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package interceptors
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// warningKey is the name of the metadata key that the server uses to send warnings, for example about deprecated
+// methods or quotas that are close to be exhausted.
+const warningKey = "warning"
+
+// Warnings creates an interceptor that extracts the warnings from the header and trailer metadata of the responses
+// and passes them to the given function.
+func Warnings(collect func(string)) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, request, response any, conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var header, trailer metadata.MD
+		opts = append(opts, grpc.Header(&header), grpc.Trailer(&trailer))
+		err := invoker(ctx, method, request, response, conn, opts...)
+		collectWarnings(collect, header)
+		collectWarnings(collect, trailer)
+		return err
+	}
+}
+
+// StreamWarnings is like Warnings, but for streaming calls. The header is checked when the first message is
+// received, and the trailer when the stream ends.
+func StreamWarnings(collect func(string)) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, conn *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, conn, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &warningsStream{
+			ClientStream: stream,
+			collect:      collect,
+		}, nil
+	}
+}
+
+type warningsStream struct {
+	grpc.ClientStream
+	collect func(string)
+	header  sync.Once
+	trailer sync.Once
+}
+
+func (s *warningsStream) RecvMsg(message any) error {
+	err := s.ClientStream.RecvMsg(message)
+	s.header.Do(func() {
+		header, headerErr := s.ClientStream.Header()
+		if headerErr == nil {
+			collectWarnings(s.collect, header)
+		}
+	})
+	if err != nil {
+		s.trailer.Do(func() {
+			collectWarnings(s.collect, s.ClientStream.Trailer())
+		})
+	}
+	return err
+}
+
+func collectWarnings(collect func(string), md metadata.MD) {
+	for _, warning := range md.Get(warningKey) {
+		if warning != "" {
+			collect(warning)
+		}
+	}
+}
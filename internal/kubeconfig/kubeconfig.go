@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package kubeconfig contains functions to manipulate kubeconfig files.
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Location returns the location of the kubeconfig file that the Kubernetes tools use by default: the first file of
+// the KUBECONFIG environment variable or else '.kube/config' inside the home directory.
+func Location() (result string, err error) {
+	for _, file := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if file != "" {
+			result = file
+			return
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(home, ".kube", "config")
+	return
+}
+
+// sections are the lists of named entries of a kubeconfig, in the order that they need to be merged: clusters and
+// users first, because contexts reference them.
+var sections = []struct {
+	list  string
+	field string
+}{
+	{"clusters", "cluster"},
+	{"users", "user"},
+	{"contexts", "context"},
+}
+
+// Merge adds the clusters, users and contexts of the source kubeconfig to the target kubeconfig. Entries that already
+// exist in the target with the same name and content are reused. Entries that have the same name but different
+// content are renamed adding the given suffix, replacing the entry with that name if it exists, as it was written by a
+// previous merge with the same suffix. That way merging again the kubeconfig of the same cluster, for example after
+// the credentials are rotated, doesn't accumulate stale entries. References from the contexts are updated
+// accordingly. It returns the merged kubeconfig and the name, possibly renamed, of the current context of the source.
+// If the switch flag is true that context is also made the current context of the result.
+func Merge(target, source []byte, suffix string, switchContext bool) (result []byte, context string, err error) {
+	targetData, err := parse(target)
+	if err != nil {
+		err = fmt.Errorf("failed to parse target kubeconfig: %w", err)
+		return
+	}
+	sourceData, err := parse(source)
+	if err != nil {
+		err = fmt.Errorf("failed to parse source kubeconfig: %w", err)
+		return
+	}
+	renames := map[string]map[string]string{}
+	for _, section := range sections {
+		renames[section.field] = map[string]string{}
+		targetEntries, _ := targetData[section.list].([]any)
+		sourceEntries, _ := sourceData[section.list].([]any)
+		for _, item := range sourceEntries {
+			entry, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := entry["name"].(string)
+			if section.field == "context" {
+				details, _ := entry["context"].(map[string]any)
+				for _, reference := range []string{"cluster", "user"} {
+					old, _ := details[reference].(string)
+					if renamed, ok := renames[reference][old]; ok {
+						details[reference] = renamed
+					}
+				}
+			}
+			existing := find(targetEntries, name)
+			if existing != nil && reflect.DeepEqual(existing, entry) {
+				renames[section.field][name] = name
+				continue
+			}
+			if existing != nil {
+				entry["name"] = name + "-" + suffix
+			}
+			renamed, _ := entry["name"].(string)
+			renames[section.field][name] = renamed
+			targetEntries = put(targetEntries, entry)
+		}
+		targetData[section.list] = targetEntries
+	}
+	current, _ := sourceData["current-context"].(string)
+	context = renames["context"][current]
+	if switchContext && context != "" {
+		targetData["current-context"] = context
+	}
+	result, err = yaml.Marshal(targetData)
+	return
+}
+
+func parse(data []byte) (result map[string]any, err error) {
+	err = yaml.Unmarshal(data, &result)
+	if err != nil {
+		return
+	}
+	if result == nil {
+		result = map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Config",
+		}
+	}
+	return
+}
+
+func find(entries []any, name string) map[string]any {
+	for _, item := range entries {
+		entry, ok := item.(map[string]any)
+		if ok && entry["name"] == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// put replaces the entry that has the same name than the given one, or adds it to the end if there is no such entry.
+func put(entries []any, entry map[string]any) []any {
+	for i, item := range entries {
+		existing, ok := item.(map[string]any)
+		if ok && existing["name"] == entry["name"] {
+			entries[i] = entry
+			return entries
+		}
+	}
+	return append(entries, entry)
+}
+
+// Write writes the kubeconfig to the given file, which is only readable by the current user, as it contains
+// credentials. The data is first written to a temporary file in the same directory that then replaces the target, so
+// that a failure doesn't leave the file truncated. If the file is a symbolic link the file that it points to is
+// replaced.
+func Write(file string, data []byte) error {
+	resolved, err := filepath.EvalSymlinks(file)
+	if err == nil {
+		file = resolved
+	}
+	dir := filepath.Dir(file)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("failed to create directory '%s': %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file in '%s': %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file '%s': %w", tmp.Name(), err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close temporary file '%s': %w", tmp.Name(), err)
+	}
+	err = os.Rename(tmp.Name(), file)
+	if err != nil {
+		return fmt.Errorf("failed to rename '%s' to '%s': %w", tmp.Name(), file, err)
+	}
+	return nil
+}
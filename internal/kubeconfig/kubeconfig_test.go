@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+// source generates a kubeconfig like the ones returned by the server, with the given token.
+func source(token string) []byte {
+	return []byte(fmt.Sprintf(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://api.example.com:6443
+users:
+- name: admin
+  user:
+    token: %s
+contexts:
+- name: admin
+  context:
+    cluster: cluster
+    user: admin
+current-context: admin
+`, token))
+}
+
+// names returns the names of the entries of the given section of the kubeconfig.
+func names(t *testing.T, data []byte, section string) []string {
+	var parsed map[string]any
+	err := yaml.Unmarshal(data, &parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result []string
+	entries, _ := parsed[section].([]any)
+	for _, item := range entries {
+		entry, _ := item.(map[string]any)
+		name, _ := entry["name"].(string)
+		result = append(result, name)
+	}
+	return result
+}
+
+func TestMergeIsIdempotent(t *testing.T) {
+	// Merge the first version, and then the same again, that should not change anything:
+	first, context, err := Merge(nil, source("one"), "123", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if context != "admin" {
+		t.Fatalf("expected context 'admin', but got '%s'", context)
+	}
+	again, _, err := Merge(first, source("one"), "123", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(again) != string(first) {
+		t.Fatalf("merging the same kubeconfig twice changed the result:\n%s", again)
+	}
+
+	// Rotating the credentials several times should add only one renamed entry:
+	current := first
+	for _, token := range []string{"two", "three", "four"} {
+		current, context, err = Merge(current, source(token), "123", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if context != "admin-123" {
+		t.Fatalf("expected context 'admin-123', but got '%s'", context)
+	}
+	for section, expected := range map[string][]string{
+		"clusters": {"cluster"},
+		"users":    {"admin", "admin-123"},
+		"contexts": {"admin", "admin-123"},
+	} {
+		actual := names(t, current, section)
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Fatalf("expected %s %v, but got %v", section, expected, actual)
+		}
+	}
+	if !containsToken(t, current, "admin-123", "four") {
+		t.Fatalf("renamed user doesn't have the last token:\n%s", current)
+	}
+}
+
+func containsToken(t *testing.T, data []byte, user, token string) bool {
+	var parsed map[string]any
+	err := yaml.Unmarshal(data, &parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := find(parsed["users"].([]any), user)
+	details, _ := entry["user"].(map[string]any)
+	return details["token"] == token
+}
+
+func TestWriteReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "config")
+	link := filepath.Join(dir, "link")
+	err := os.WriteFile(target, []byte("old"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(target, link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Write(link, []byte("new"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Fatalf("expected 'new', but got '%s'", data)
+	}
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("symbolic link was replaced")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected only the file and the link, but got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,548 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package listing contains the implementation of the 'get' commands: the flags, the retrieval of the objects, the
+// output formats, the streaming and the watching of changes. These are the same for all the types of objects, so each
+// command only describes what depends on the type, like the columns of the table and how to call the server.
+package listing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/innabox/fulfillment-cli/internal/anonymize"
+	eventsv1 "github.com/innabox/fulfillment-cli/internal/api/events/v1"
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/experimental"
+	"github.com/innabox/fulfillment-cli/internal/expressions"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paging"
+	"github.com/innabox/fulfillment-cli/internal/snapshots"
+	"github.com/innabox/fulfillment-cli/internal/tables"
+	"github.com/innabox/fulfillment-cli/internal/terminal"
+	"github.com/innabox/fulfillment-cli/internal/watch"
+)
+
+// Kind contains the details of the 'get' command that depend on the type of object.
+type Kind[T output.Identified] struct {
+	// Name is the name of the type, for example 'clusterorder'. It is used in the output of the 'name' format, in the
+	// snapshots, and to find the columns defined in the configuration.
+	Name string
+
+	// Plural is the name used for several objects of the type in messages, for example 'cluster orders'.
+	Plural string
+
+	// Variable is the name of the variable that contains the object in CEL expressions, for example 'order'.
+	Variable string
+
+	// Message is an empty message of the type, used to compile the CEL expressions.
+	Message T
+
+	// Event is the name of the field of the events that contains the objects of the type, for example
+	// 'cluster_order'.
+	Event string
+
+	// ColumnsExample and SortExample are the examples used in the help of the '--columns' and '--sort-by' flags.
+	ColumnsExample string
+	SortExample    string
+
+	// Columns are the default columns of the table.
+	Columns []output.Column
+
+	// Row calculates the values of the default columns for an object.
+	Row func(object T) []string
+
+	// List retrieves one page of objects.
+	List func(ctx context.Context, conn *grpc.ClientConn, offset, limit int32) (items []T, total int32, err error)
+
+	// Get retrieves one object given its identifier.
+	Get func(ctx context.Context, conn *grpc.ClientConn, id string) (T, error)
+
+	// FromEvent returns the object contained in the event, and false if it doesn't contain an object of the type.
+	FromEvent func(event *eventsv1.Event) (T, bool)
+}
+
+// Setup adds the flags of the 'get' commands to the given command, and sets the function that runs it.
+func Setup[T output.Identified](command *cobra.Command, kind *Kind[T]) {
+	runner := &runnerContext[T]{
+		kind: kind,
+	}
+	command.RunE = runner.run
+	flags := command.Flags()
+	flags.StringVar(
+		&runner.jq,
+		"jq",
+		"",
+		"Process the JSON representation of the result with the given jq expression",
+	)
+	flags.StringArrayVarP(
+		&runner.outputs,
+		"output",
+		"o",
+		[]string{"table"},
+		"Output format, one of 'table', 'wide', 'table-json', 'json', 'yaml', 'name', 'jsonpath=TEMPLATE' or "+
+			"'go-template=TEMPLATE'. The 'name' format writes one line per object with its type and identifier, "+
+			"like '"+kind.Name+"/123'. The 'table-json' format writes the columns and cells of the table, with "+
+			"raw values and types, as JSON. The templates are evaluated for each object using its JSON "+
+			"representation, for example 'jsonpath={.id}{\"\\n\"}' or 'go-template={{.id}}{{\"\\n\"}}'. "+
+			"The flag can be repeated, and adding '=PATH' to the 'table', 'wide', 'json' or 'yaml' formats "+
+			"writes the result also to that file, for example '-o table -o json="+kind.Name+"s.json'.",
+	)
+	flags.StringVar(
+		&runner.columns,
+		"columns",
+		"",
+		"Comma separated list of columns to display instead of the default ones, or the ones defined in the "+
+			"'tables' directory of the configuration. Each column has the format "+
+			"'HEADER=EXPRESSION', where the expression is a CEL expression that uses the '"+kind.Variable+"' "+
+			"variable, or just 'HEADER' to select one of the default columns. For example '"+
+			kind.ColumnsExample+"'.",
+	)
+	flags.BoolVar(
+		&runner.noHeaders,
+		"no-headers",
+		false,
+		"Don't display the headers of the table. When there is only one row and one column the value is "+
+			"displayed as is, so that it can be used in scripts.",
+	)
+	flags.BoolVar(
+		&runner.noTruncate,
+		"no-truncate",
+		false,
+		"Don't truncate long values. By default values longer than the maximum width of their column are "+
+			"truncated, and when the output is a terminal the widest columns are also truncated so that the "+
+			"table fits in its width.",
+	)
+	flags.BoolVar(
+		&runner.watch,
+		"watch",
+		false,
+		"After displaying the "+kind.Plural+" keep watching for changes and display them",
+	)
+	flags.StringVar(
+		&runner.sortBy,
+		"sort-by",
+		"",
+		"CEL expression used to sort the "+kind.Plural+", using the '"+kind.Variable+"' variable. For example "+
+			"'"+kind.SortExample+"'.",
+	)
+	flags.BoolVar(
+		&runner.reverse,
+		"reverse",
+		false,
+		"Sort the "+kind.Plural+" in descending order. Requires the '--sort-by' flag.",
+	)
+	flags.BoolVar(
+		&runner.stream,
+		"stream",
+		false,
+		"Write the "+kind.Plural+" as they are received from the server, instead of collecting all of them "+
+			"first, so that large lists can be processed with little memory. Requires the 'json' or 'yaml' "+
+			"output formats, and then the result is in JSON Lines format or has one YAML document per object.",
+	)
+	flags.IntVar(
+		&runner.concurrency,
+		"concurrency",
+		paging.DefaultConcurrency,
+		"Maximum number of "+kind.Plural+" retrieved simultaneously when identifiers are given",
+	)
+	experimental.MarkFlag(flags, "watch")
+	runner.anonymize = anonymize.AddFlags(flags)
+}
+
+type runnerContext[T output.Identified] struct {
+	kind        *Kind[T]
+	jq          string
+	outputs     []string
+	files       []output.Destination
+	columns     string
+	noHeaders   bool
+	noTruncate  bool
+	watch       bool
+	sortBy      string
+	reverse     bool
+	stream      bool
+	concurrency int
+	custom      *expressions.Columns
+	sorter      *expressions.Expression
+	anonymize   *anonymize.Flags
+}
+
+func (c *runnerContext[T]) run(cmd *cobra.Command, args []string) error {
+	// Check that the flags are compatible:
+	if c.jq != "" && c.watch {
+		return fmt.Errorf("flags '--jq' and '--watch' can't be used together")
+	}
+	if len(args) > 0 && (c.watch || c.stream) {
+		return fmt.Errorf("flags '--watch' and '--stream' can't be used when identifiers are given")
+	}
+	if c.concurrency <= 0 {
+		return fmt.Errorf("concurrency should be positive, but it is %d", c.concurrency)
+	}
+
+	// Check the output format:
+	primary, files, err := output.ParseOutputs(c.outputs)
+	if err != nil {
+		return err
+	}
+	if len(files) > 0 && (c.watch || c.stream) {
+		return fmt.Errorf("writing the output to files can't be used together with '--watch' or '--stream'")
+	}
+	c.files = files
+	format, argument, _ := strings.Cut(primary, "=")
+	switch format {
+	case "table", "wide":
+	case "json", "yaml", "table-json", "name":
+		if c.watch {
+			return fmt.Errorf("output format '%s' can't be used together with '--watch'", format)
+		}
+	case "jsonpath", "go-template":
+		if argument == "" {
+			return fmt.Errorf("output format '%s' requires a template, for example '%s={.id}'", format, format)
+		}
+		if c.watch {
+			return fmt.Errorf("output format '%s' can't be used together with '--watch'", format)
+		}
+	default:
+		return fmt.Errorf(
+			"output format '%s' isn't supported, it should be 'table', 'wide', 'table-json', 'json', 'yaml', "+
+				"'name', 'jsonpath' or 'go-template'",
+			primary,
+		)
+	}
+
+	// Check the streaming flag:
+	if c.stream {
+		if format != "json" && format != "yaml" {
+			return fmt.Errorf("flag '--stream' requires the 'json' or 'yaml' output formats")
+		}
+		if c.jq != "" || c.sortBy != "" {
+			return fmt.Errorf("flag '--stream' can't be used together with '--jq' or '--sort-by'")
+		}
+	}
+
+	// Compile the sort expression, if any:
+	if c.reverse && c.sortBy == "" {
+		return fmt.Errorf("flag '--reverse' requires the '--sort-by' flag")
+	}
+	if c.sortBy != "" {
+		sorter, err := expressions.Compile(c.kind.Variable, c.kind.Message, c.sortBy)
+		if err != nil {
+			return err
+		}
+		c.sorter = sorter
+	}
+
+	// Replace the sensitive values with pseudonyms if requested:
+	err = c.anonymize.Apply()
+	if err != nil {
+		return err
+	}
+
+	// Get the context:
+	ctx := cmd.Context()
+
+	// Get the configuration:
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Address == "" {
+		return fmt.Errorf("there is no configuration, run the 'login' command")
+	}
+
+	// Compile the custom columns, if any. The columns given in the command line take precedence over the table
+	// defined by the user in the configuration directory, and over the extra columns of the configuration file.
+	if c.columns != "" {
+		custom, err := expressions.CompileColumns(c.kind.Variable, c.kind.Message, c.columns, c.kind.Columns)
+		if err != nil {
+			return err
+		}
+		c.custom = custom
+	} else {
+		custom, err := tables.Columns(
+			c.kind.Name, c.kind.Variable, c.kind.Message, c.kind.Columns, cfg.Columns.For(c.kind.Name),
+		)
+		if err != nil {
+			return err
+		}
+		c.custom = custom
+	}
+
+	// Create the gRPC connection from the configuration:
+	conn, err := cfg.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	// Prepare the function that retrieves one page of objects:
+	fetch := func(ctx context.Context, offset, limit int32) ([]T, int32, error) {
+		return c.kind.List(ctx, conn, offset, limit)
+	}
+
+	// When streaming write the objects as soon as they are received, instead of collecting all of them first:
+	if c.stream {
+		pager := paging.NewPager(fetch, 0)
+		var save func(object T)
+		if cfg.SaveSnapshots {
+			save = c.save
+		}
+		err = output.Stream(ctx, os.Stdout, pager, format, save)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", c.kind.Plural, err)
+		}
+		return nil
+	}
+
+	// Get the objects requested by identifier, retrieving several of them simultaneously, or else the complete list:
+	var objects []T
+	if len(args) > 0 {
+		objects, err = paging.GetAll(ctx, args, c.concurrency, func(ctx context.Context, id string) (T, error) {
+			return c.kind.Get(ctx, conn, id)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", c.kind.Plural, err)
+		}
+	} else {
+		objects, err = paging.ListAll(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", c.kind.Plural, err)
+		}
+	}
+
+	// Sort the objects if requested:
+	if c.sorter != nil {
+		err = expressions.Sort(c.sorter, objects, c.reverse)
+		if err != nil {
+			return fmt.Errorf("failed to sort %s: %w", c.kind.Plural, err)
+		}
+	}
+
+	// Save snapshots of the objects, if enabled in the configuration, so that the 'changes' command can later find
+	// what changed:
+	if cfg.SaveSnapshots {
+		for _, object := range objects {
+			c.save(object)
+		}
+	}
+
+	// Write the objects to the output files, if any:
+	for _, file := range c.files {
+		err = output.WriteFile(file, output.FileMode(cmd.Annotations), objects,
+			func(writer io.Writer, wide bool) error {
+				return c.writeTable(writer, wide, objects)
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// If a jq expression was given then use it to process the result instead of displaying the table:
+	if c.jq != "" {
+		return output.Query(os.Stdout, c.jq, objects)
+	}
+
+	// Use the requested output format, unless it is one of the table formats:
+	switch format {
+	case "table-json":
+		return c.writeTableJSON(os.Stdout, objects)
+	case "json":
+		return output.JSON(os.Stdout, objects)
+	case "name":
+		return output.Names(os.Stdout, c.kind.Name, objects)
+	case "yaml":
+		return output.YAML(os.Stdout, objects)
+	case "jsonpath":
+		return output.JSONPath(os.Stdout, argument, objects)
+	case "go-template":
+		return output.GoTemplate(os.Stdout, argument, objects)
+	}
+
+	// Display the objects. When watching there are additional columns that show the type of the event and the fields
+	// that changed.
+	columns := c.tableColumns()
+	if c.watch {
+		columns = append([]output.Column{{Header: "EVENT"}}, columns...)
+		columns = append(columns, output.Column{Header: "CHANGED", Max: 60})
+	}
+	table := output.NewTable(os.Stdout, columns...)
+	table.SetWide(format == "wide")
+	table.SetNoHeaders(c.noHeaders)
+	table.SetNoTruncate(c.noTruncate)
+	table.SetMaxWidth(terminal.Width(os.Stdout))
+	rows := make([][]string, len(objects))
+	for i, object := range objects {
+		rows[i], err = c.row(object)
+		if err != nil {
+			return err
+		}
+		if c.watch {
+			rows[i] = append([]string{"EXISTING"}, rows[i]...)
+			rows[i] = append(rows[i], "-")
+		}
+	}
+	table.Write(rows)
+
+	// Watch for changes if requested:
+	if c.watch {
+		return c.watchChanges(ctx, conn, table, objects)
+	}
+
+	return nil
+}
+
+// save saves a snapshot of the object. Failing to do so shouldn't prevent displaying the result, so errors are only
+// reported as warnings.
+func (c *runnerContext[T]) save(object T) {
+	err := snapshots.Save(c.kind.Name, object.GetId(), object)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// writeTable writes the table of objects to the given writer, without the additional columns used when watching.
+func (c *runnerContext[T]) writeTable(writer io.Writer, wide bool, objects []T) error {
+	table := output.NewTable(writer, c.tableColumns()...)
+	table.SetWide(wide)
+	table.SetNoHeaders(c.noHeaders)
+	table.SetNoTruncate(c.noTruncate)
+	rows := make([][]string, len(objects))
+	for i, object := range objects {
+		row, err := c.row(object)
+		if err != nil {
+			return err
+		}
+		rows[i] = row
+	}
+	table.Write(rows)
+	return nil
+}
+
+// writeTableJSON writes the model of the table of objects to the given writer, including the raw values of the cells.
+func (c *runnerContext[T]) writeTableJSON(writer io.Writer, objects []T) error {
+	rows := make([][]output.Cell, len(objects))
+	for i, object := range objects {
+		row, err := c.cells(object)
+		if err != nil {
+			return err
+		}
+		rows[i] = row
+	}
+	return output.TableJSON(writer, c.tableColumns(), rows)
+}
+
+// tableColumns returns the columns of the table, either the default ones or the custom ones given with the
+// '--columns' flag.
+func (c *runnerContext[T]) tableColumns() []output.Column {
+	if c.custom != nil {
+		return c.custom.Table()
+	}
+	return c.kind.Columns
+}
+
+// row calculates the values of the columns of the table for the given object.
+func (c *runnerContext[T]) row(object T) ([]string, error) {
+	object, err := output.Process(object)
+	if err != nil {
+		return nil, err
+	}
+	values := c.kind.Row(object)
+	if c.custom != nil {
+		return c.custom.Row(object, values)
+	}
+	return values, nil
+}
+
+// cells is like row, but it also returns the raw values and types of the columns calculated with CEL expressions.
+func (c *runnerContext[T]) cells(object T) ([]output.Cell, error) {
+	object, err := output.Process(object)
+	if err != nil {
+		return nil, err
+	}
+	values := c.kind.Row(object)
+	if c.custom != nil {
+		return c.custom.Cells(object, values)
+	}
+	return output.TextCells(values), nil
+}
+
+// watchChanges watches the events of the objects and adds a row to the table for each of them. The values that
+// changed since the previous version of the same object are highlighted, and the paths of the fields that changed are
+// displayed in the last column.
+func (c *runnerContext[T]) watchChanges(ctx context.Context, conn *grpc.ClientConn, table *output.Table,
+	objects []T) error {
+	// Remember the current versions, so that it is possible to find what changed:
+	tracker := snapshots.NewTracker()
+	previous := map[string][]string{}
+	for _, object := range objects {
+		_, err := tracker.Update(object.GetId(), object)
+		if err != nil {
+			return err
+		}
+		previous[object.GetId()], err = c.row(object)
+		if err != nil {
+			return err
+		}
+	}
+	table.SetHighlight(func(text string) string {
+		return terminal.Bold(os.Stdout, text)
+	})
+
+	// Process the events, the connection is automatically restored if it is lost:
+	filter := fmt.Sprintf("has(event.%s)", c.kind.Event)
+	err := watch.Events(ctx, conn, filter, os.Stderr, func(event *eventsv1.Event) error {
+		object, ok := c.kind.FromEvent(event)
+		if !ok {
+			return nil
+		}
+		id := object.GetId()
+		kind := strings.TrimPrefix(event.Type.String(), "EVENT_TYPE_OBJECT_")
+		row, err := c.row(object)
+		if err != nil {
+			return err
+		}
+		var changed []string
+		var highlighted []bool
+		if event.Type == eventsv1.EventType_EVENT_TYPE_OBJECT_DELETED {
+			tracker.Forget(id)
+			delete(previous, id)
+		} else {
+			changed, err = tracker.Update(id, object)
+			if err != nil {
+				return err
+			}
+			highlighted = output.Changed(previous[id], row)
+			previous[id] = row
+		}
+		summary := "-"
+		if len(changed) > 0 {
+			summary = strings.Join(changed, ",")
+		}
+		table.AppendHighlighted(
+			append(append([]string{kind}, row...), summary),
+			append([]bool{false}, highlighted...),
+		)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", c.kind.Plural, err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package mutation contains the flags and functions shared by the commands that modify objects in the server, like
+// 'create' and 'delete', to support dry runs and to skip the confirmation prompts.
+package mutation
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/innabox/fulfillment-cli/internal/terminal"
+)
+
+// Values of the '--dry-run' flag. There is no 'server' value because the requests of the API don't have a 'dry_run'
+// field yet, so the server can't be asked to only validate them.
+const (
+	// DryRunNone means that the changes are actually made. This is the default.
+	DryRunNone = "none"
+
+	// DryRunClient means that the request is validated and printed by the tool, but not sent to the server.
+	DryRunClient = "client"
+)
+
+// Flags contains the values of the flags that control how the changes are made.
+type Flags struct {
+	DryRun string
+	Yes    bool
+}
+
+// AddFlags adds the '--dry-run', '--yes' and '--no-prompt' flags to the given flag set. Note that '--dry-run' without
+// a value is equivalent to '--dry-run=client'.
+func AddFlags(flags *pflag.FlagSet) *Flags {
+	result := &Flags{}
+	flags.StringVar(
+		&result.DryRun,
+		"dry-run",
+		DryRunNone,
+		"Don't make the changes. With 'client' the request is validated and displayed but not sent to the server. "+
+			"This is currently the only supported value.",
+	)
+	flags.Lookup("dry-run").NoOptDefVal = DryRunClient
+	flags.BoolVar(
+		&result.Yes,
+		"yes",
+		false,
+		"Don't ask for confirmation",
+	)
+	flags.BoolVar(
+		&result.Yes,
+		"no-prompt",
+		false,
+		"Same as '--yes'",
+	)
+	return result
+}
+
+// Validate checks the values of the flags.
+func (f *Flags) Validate() error {
+	switch f.DryRun {
+	case DryRunNone, DryRunClient:
+		return nil
+	default:
+		return fmt.Errorf(
+			"value '%s' of flag '--dry-run' isn't valid, valid values are '%s' and '%s'",
+			f.DryRun, DryRunNone, DryRunClient,
+		)
+	}
+}
+
+// Client returns true if a client side dry run was requested.
+func (f *Flags) Client() bool {
+	return f.DryRun == DryRunClient
+}
+
+// Confirm asks the user the given question, unless the '--yes' flag was used. When the input isn't a terminal it
+// returns an error instead of waiting for an answer that will never come. The question is written to the standard
+// error, as the standard output may be redirected to a file with the '--output-file' flag.
+func (f *Flags) Confirm(question string) (result bool, err error) {
+	if f.Yes {
+		result = true
+		return
+	}
+	if !terminal.IsTerminal(os.Stdin) {
+		err = fmt.Errorf("confirmation is required but the input isn't a terminal, use the '--yes' flag to skip it")
+		return
+	}
+	return terminal.Confirm(os.Stdin, os.Stderr, question)
+}
+
+// Suffix returns the text that should be added to the messages that describe the changes, so that it is clear that
+// they weren't actually made.
+func (f *Flags) Suffix() string {
+	switch f.DryRun {
+	case DryRunClient:
+		return " (dry run)"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Destination is an additional output format that is written to a file instead of to the standard output.
+type Destination struct {
+	Format string
+	Path   string
+}
+
+// fileFormats are the output formats that can be written to a file using the 'FORMAT=PATH' syntax. The template
+// formats aren't included because for them the text after the '=' is the template.
+var fileFormats = map[string]bool{
+	"table": true,
+	"wide":  true,
+	"json":  true,
+	"yaml":  true,
+}
+
+// ParseOutputs splits the values of a repeatable '--output' flag into the format that is written to the standard
+// output and the formats that are written to files. Values like 'json=clusters.json' are written to files, and the
+// rest are written to the standard output, so only one of them is allowed. If all the values are written to files
+// then the standard output uses the 'table' format.
+func ParseOutputs(values []string) (primary string, files []Destination, err error) {
+	for _, value := range values {
+		format, path, _ := strings.Cut(value, "=")
+		if fileFormats[format] && path != "" {
+			files = append(files, Destination{
+				Format: format,
+				Path:   path,
+			})
+			continue
+		}
+		if primary != "" {
+			err = fmt.Errorf(
+				"output formats '%s' and '%s' would both be written to the standard output, add '=PATH' to "+
+					"one of them to write it to a file instead",
+				primary, value,
+			)
+			return
+		}
+		primary = value
+	}
+	if primary == "" {
+		primary = "table"
+	}
+	return
+}
+
+// WriteFile writes the messages to the file of the destination. The JSON and YAML formats are written directly, and
+// for the table formats the given function is called with the writer and a flag that indicates if the wide format
+// was requested. Like for the '--output-file' flag, the content is written to a temporary file that is renamed to the
+// destination, with the given permissions, only when it is complete. Use FileMode to calculate the permissions.
+func WriteFile(destination Destination, mode os.FileMode, items any,
+	table func(writer io.Writer, wide bool) error) error {
+	file, err := createTemp(destination.Path)
+	if err != nil {
+		return err
+	}
+	switch destination.Format {
+	case "json":
+		err = JSON(file, items)
+	case "yaml":
+		err = YAML(file, items)
+	default:
+		err = table(file, destination.Format == "wide")
+	}
+	if err != nil {
+		replace(file, destination.Path, mode, false)
+		return fmt.Errorf("failed to write output file '%s': %w", destination.Path, err)
+	}
+	return replace(file, destination.Path, mode, true)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orders.txt")
+	err := os.WriteFile(path, []byte("old"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	destination := Destination{
+		Format: "table",
+		Path:   path,
+	}
+
+	// Check that a failure preserves the previous content and doesn't leave temporary files:
+	err = WriteFile(destination, 0600, nil, func(writer io.Writer, wide bool) error {
+		io.WriteString(writer, "partial")
+		return errors.New("failed")
+	})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "old" {
+		t.Fatalf("expected the previous content, but got '%s'", data)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the destination file, but got %d files", len(entries))
+	}
+
+	// Check that success replaces the content and sets the permissions:
+	err = WriteFile(destination, 0600, nil, func(writer io.Writer, wide bool) error {
+		_, err := io.WriteString(writer, "new")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Fatalf("expected 'new', but got '%s'", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected permissions 0600, but got %o", info.Mode().Perm())
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	if mode := FileMode(nil); mode != 0644 {
+		t.Fatalf("expected 0644, but got %o", mode)
+	}
+	if mode := FileMode(map[string]string{SensitiveAnnotation: "true"}); mode != 0600 {
+		t.Fatalf("expected 0600, but got %o", mode)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Enum returns the name of an enum value without the prefix that the protocol buffers style guide requires. For
+// example, for 'CLUSTER_STATE_READY' it returns 'READY'.
+//
+// The prefix is calculated from the 'UNSPECIFIED' value of the enum, if it has one, or else from the name of the enum
+// type. If the value doesn't have that prefix then the complete name is returned. If the number doesn't correspond
+// to any value known to this version of the tool, which happens when the server is newer, then the number is
+// returned.
+func Enum(value protoreflect.Enum) string {
+	descriptor := value.Descriptor()
+	valueDescriptor := descriptor.Values().ByNumber(value.Number())
+	if valueDescriptor == nil {
+		return strconv.Itoa(int(value.Number()))
+	}
+	name := string(valueDescriptor.Name())
+	prefix := enumPrefix(descriptor)
+	if prefix != "" && strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+		return name[len(prefix):]
+	}
+	return name
+}
+
+// enumPrefix calculates the prefix that is common to all the values of the given enum type.
+func enumPrefix(descriptor protoreflect.EnumDescriptor) string {
+	values := descriptor.Values()
+	for i := 0; i < values.Len(); i++ {
+		name := string(values.Get(i).Name())
+		if strings.HasSuffix(name, "_UNSPECIFIED") {
+			return strings.TrimSuffix(name, "UNSPECIFIED")
+		}
+	}
+	return upperSnakeCase(string(descriptor.Name())) + "_"
+}
+
+// upperSnakeCase converts a name like 'ClusterOrderState' into 'CLUSTER_ORDER_STATE'.
+func upperSnakeCase(name string) string {
+	buffer := &strings.Builder{}
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			buffer.WriteRune('_')
+		}
+		buffer.WriteRune(unicode.ToUpper(r))
+	}
+	return buffer.String()
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SensitiveAnnotation is the annotation that commands whose output may contain sensitive data, like kubeconfigs or
+// template parameters with pull secrets, add with the value 'true'. When the output of those commands is redirected
+// to a file with the '--output-file' flag the file is only readable by the user.
+const SensitiveAnnotation = "fulfillment-cli/sensitive-output"
+
+// FileMode returns the permissions of the files that contain the output of a command with the given annotations: only
+// readable by the user if the command has the SensitiveAnnotation, and readable by everyone otherwise.
+func FileMode(annotations map[string]string) os.FileMode {
+	if annotations[SensitiveAnnotation] == "true" {
+		return 0600
+	}
+	return 0644
+}
+
+// redirection contains the details of the redirection of the standard output done by RedirectStdout.
+type redirection struct {
+	path   string
+	mode   os.FileMode
+	file   *os.File
+	stdout *os.File
+}
+
+// redirected is the current redirection, or nil if the standard output isn't redirected.
+var redirected *redirection
+
+// RedirectStdout replaces os.Stdout with a temporary file in the same directory than the given path, so that all the
+// output of the command is written to it. The FinishStdout function must be called when the command finishes, to
+// rename the temporary file to the given path or to discard it. This is intended for the '--output-file' command line
+// flag.
+func RedirectStdout(path string, mode os.FileMode) error {
+	if redirected != nil {
+		return fmt.Errorf("standard output is already redirected to '%s'", redirected.path)
+	}
+	file, err := createTemp(path)
+	if err != nil {
+		return err
+	}
+	redirected = &redirection{
+		path:   path,
+		mode:   mode,
+		file:   file,
+		stdout: os.Stdout,
+	}
+	os.Stdout = file
+	return nil
+}
+
+// FinishStdout restores the standard output replaced by RedirectStdout. If the command succeeded the temporary file
+// is renamed to the requested path, with the requested permissions, otherwise it is discarded, so that the previous
+// content of the file isn't replaced by incomplete results. It does nothing if the standard output isn't redirected.
+func FinishStdout(succeeded bool) error {
+	if redirected == nil {
+		return nil
+	}
+	current := redirected
+	redirected = nil
+	os.Stdout = current.stdout
+	return replace(current.file, current.path, current.mode, succeeded)
+}
+
+// createTemp creates a temporary file in the same directory than the given path, so that it can later be renamed to
+// that path with the replace function.
+func createTemp(path string) (result *os.File, err error) {
+	dir := filepath.Dir(path)
+	result, err = os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		err = fmt.Errorf("failed to create temporary file in '%s': %w", dir, err)
+	}
+	return
+}
+
+// replace closes the temporary file created by createTemp and, if keep is true, renames it to the given path with
+// the given permissions. Otherwise the temporary file is discarded and the previous content of the path is preserved.
+// Note that the permissions are set before renaming, so the content is never visible with other permissions.
+func replace(file *os.File, path string, mode os.FileMode, keep bool) error {
+	defer os.Remove(file.Name())
+	err := file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close temporary file '%s': %w", file.Name(), err)
+	}
+	if !keep {
+		return nil
+	}
+	err = os.Chmod(file.Name(), mode)
+	if err != nil {
+		return fmt.Errorf("failed to change permissions of '%s': %w", file.Name(), err)
+	}
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to rename '%s' to '%s': %w", file.Name(), path, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+)
+
+// Query evaluates the given jq expression against the JSON representation of the input and writes the results to the
+// given writer, one per line. The input can be a protocol buffers message or a slice of messages. Results that are
+// strings are written without quotes, like 'jq --raw-output' does, so that they can be easily used in scripts.
+func Query(writer io.Writer, expr string, input any) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("failed to parse jq expression '%s': %w", expr, err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return fmt.Errorf("failed to compile jq expression '%s': %w", expr, err)
+	}
+	value, err := toValue(input)
+	if err != nil {
+		return err
+	}
+	iter := code.Run(value)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := result.(error); ok {
+			return fmt.Errorf("failed to evaluate jq expression '%s': %w", expr, err)
+		}
+		if text, ok := result.(string); ok {
+			fmt.Fprintf(writer, "%s\n", text)
+			continue
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal jq result: %w", err)
+		}
+		fmt.Fprintf(writer, "%s\n", data)
+	}
+	return nil
+}
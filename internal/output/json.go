@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	// Template parameters are usually packed inside 'Any' messages using these well known types, and we need to have
+	// them in the registry in order to convert them to JSON:
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// marshalOptions are the options used to convert messages to JSON. Note that we use the names of the fields as they
+// appear in the protocol buffers specification, for example 'api_url' instead of 'apiUrl'.
+var marshalOptions = protojson.MarshalOptions{
+	UseProtoNames: true,
+}
+
+// toValue converts a protocol buffers message, or a slice of messages, into the generic representation of its JSON
+// equivalent, using maps, slices, strings, numbers and booleans. The registered processors are applied to the messages
+// before converting them.
+func toValue(input any) (result any, err error) {
+	if message, ok := input.(proto.Message); ok {
+		message, err = Process(message)
+		if err != nil {
+			return
+		}
+		var data []byte
+		data, err = marshalOptions.Marshal(message)
+		if err != nil {
+			err = fmt.Errorf("failed to marshal message: %w", err)
+			return
+		}
+		err = json.Unmarshal(data, &result)
+		if err != nil {
+			err = fmt.Errorf("failed to unmarshal message: %w", err)
+		}
+		return
+	}
+	value := reflect.ValueOf(input)
+	if value.Kind() != reflect.Slice {
+		err = fmt.Errorf("expected a message or a slice of messages, but got '%T'", input)
+		return
+	}
+	items := make([]any, value.Len())
+	for i := range items {
+		items[i], err = toValue(value.Index(i).Interface())
+		if err != nil {
+			return
+		}
+	}
+	result = items
+	return
+}
+
+// JSONLine writes the JSON representation of the message to the writer, in one line, as required by the newline
+// delimited JSON format.
+func JSONLine(writer io.Writer, message proto.Message) error {
+	message, err := Process(message)
+	if err != nil {
+		return err
+	}
+	data, err := marshalOptions.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	buffer := &bytes.Buffer{}
+	err = json.Compact(buffer, data)
+	if err != nil {
+		return fmt.Errorf("failed to compact message: %w", err)
+	}
+	buffer.WriteByte('\n')
+	_, err = writer.Write(buffer.Bytes())
+	return err
+}
+
+// JSON writes the indented JSON representation of the message, or slice of messages, to the writer.
+func JSON(writer io.Writer, input any) error {
+	value, err := toValue(input)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+	fmt.Fprintf(writer, "%s\n", data)
+	return nil
+}
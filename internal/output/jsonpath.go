@@ -0,0 +1,297 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath evaluates the given JSONPath template for each object, using the JSON representation of the object, and
+// writes the results to the writer. The input can be a protocol buffers message or a slice of messages.
+//
+// The syntax is the subset of the JSONPath templates supported by kubectl that is useful for individual objects: text
+// outside braces is written as is, and the braces can contain a quoted string, like '{"\n"}', or a path, like
+// '{.status.api_url}', '{.parameters[0].name}' or '{.parameters[*].name}'. Multiple results are separated by spaces.
+// Paths that don't exist produce no output. Nothing is added between the results of different objects.
+func JSONPath(writer io.Writer, text string, input any) error {
+	segments, err := parseJSONPath(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse JSONPath template '%s': %w", text, err)
+	}
+	objects, err := toObjects(input)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		buffer := &strings.Builder{}
+		for _, segment := range segments {
+			if segment.path == nil {
+				buffer.WriteString(segment.text)
+				continue
+			}
+			values := evaluateJSONPath(segment.path, object)
+			for i, value := range values {
+				if i > 0 {
+					buffer.WriteString(" ")
+				}
+				err = writeJSONPathValue(buffer, value)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		_, err = io.WriteString(writer, buffer.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonPathSegment is a piece of a JSONPath template, either literal text or a path.
+type jsonPathSegment struct {
+	text string
+	path []jsonPathStep
+}
+
+// jsonPathStep is one step of a path: the name of a field, an index, or a wildcard that selects all the elements.
+type jsonPathStep struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func parseJSONPath(text string) (result []jsonPathSegment, err error) {
+	for text != "" {
+		start := strings.Index(text, "{")
+		if start == -1 {
+			result = append(result, jsonPathSegment{text: text})
+			return
+		}
+		if start > 0 {
+			result = append(result, jsonPathSegment{text: text[:start]})
+		}
+		end := findUnquoted(text[start:], '}')
+		if end == -1 {
+			err = fmt.Errorf("unclosed brace at position %d", start)
+			return
+		}
+		expr := strings.TrimSpace(text[start+1 : start+end])
+		text = text[start+end+1:]
+		if strings.HasPrefix(expr, "\"") || strings.HasPrefix(expr, "'") {
+			var literal string
+			literal, err = unquoteJSONPath(expr)
+			if err != nil {
+				return
+			}
+			result = append(result, jsonPathSegment{text: literal})
+			continue
+		}
+		var path []jsonPathStep
+		path, err = parseJSONPathSteps(expr)
+		if err != nil {
+			return
+		}
+		result = append(result, jsonPathSegment{path: path})
+	}
+	return
+}
+
+// findUnquoted returns the position of the first occurrence of the given character that isn't inside a single or
+// double quoted string, or -1 if there is no such occurrence. Inside the strings a backslash escapes the next
+// character.
+func findUnquoted(text string, char byte) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == char:
+			return i
+		}
+	}
+	return -1
+}
+
+// unquoteJSONPath returns the value of a single or double quoted string literal. Double quoted strings use the Go
+// syntax. In single quoted strings a single quote can be included escaping it with a backslash.
+func unquoteJSONPath(expr string) (result string, err error) {
+	quote := expr[0]
+	if len(expr) < 2 || expr[len(expr)-1] != quote {
+		err = fmt.Errorf("invalid string literal %s", expr)
+		return
+	}
+	converted := expr
+	if quote == '\'' {
+		inner := expr[1 : len(expr)-1]
+		buffer := &strings.Builder{}
+		buffer.WriteByte('"')
+		for i := 0; i < len(inner); i++ {
+			c := inner[i]
+			switch {
+			case c == '\\' && i+1 < len(inner) && inner[i+1] == '\'':
+				buffer.WriteByte('\'')
+				i++
+			case c == '\\' && i+1 < len(inner):
+				buffer.WriteByte(c)
+				buffer.WriteByte(inner[i+1])
+				i++
+			case c == '"':
+				buffer.WriteString("\\\"")
+			default:
+				buffer.WriteByte(c)
+			}
+		}
+		buffer.WriteByte('"')
+		converted = buffer.String()
+	}
+	result, err = strconv.Unquote(converted)
+	if err != nil {
+		err = fmt.Errorf("invalid string literal %s", expr)
+	}
+	return
+}
+
+func parseJSONPathSteps(expr string) (result []jsonPathStep, err error) {
+	rest := strings.TrimPrefix(expr, "$")
+	if rest == "" {
+		return
+	}
+	if rest[0] != '.' && rest[0] != '[' {
+		err = fmt.Errorf("path '%s' should start with '.'", expr)
+		return
+	}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			switch name {
+			case "":
+				if rest != "" || len(result) > 0 {
+					err = fmt.Errorf("path '%s' contains an empty field name", expr)
+					return
+				}
+			case "*":
+				result = append(result, jsonPathStep{wildcard: true})
+			default:
+				result = append(result, jsonPathStep{field: name})
+			}
+		case '[':
+			end := findUnquoted(rest, ']')
+			if end == -1 {
+				err = fmt.Errorf("path '%s' contains an unclosed bracket", expr)
+				return
+			}
+			inside := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inside == "*":
+				result = append(result, jsonPathStep{wildcard: true})
+			case strings.HasPrefix(inside, "'") || strings.HasPrefix(inside, "\""):
+				var name string
+				name, err = unquoteJSONPath(inside)
+				if err != nil {
+					return
+				}
+				result = append(result, jsonPathStep{field: name})
+			default:
+				var index int
+				index, err = strconv.Atoi(inside)
+				if err != nil {
+					err = fmt.Errorf("path '%s' contains invalid index '%s'", expr, inside)
+					return
+				}
+				result = append(result, jsonPathStep{index: index, isIndex: true})
+			}
+		default:
+			err = fmt.Errorf("path '%s' contains unexpected character '%c'", expr, rest[0])
+			return
+		}
+	}
+	return
+}
+
+func evaluateJSONPath(path []jsonPathStep, value any) []any {
+	current := []any{value}
+	for _, step := range path {
+		var next []any
+		for _, item := range current {
+			switch typed := item.(type) {
+			case map[string]any:
+				if step.wildcard {
+					keys := make([]string, 0, len(typed))
+					for key := range typed {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, typed[key])
+					}
+					continue
+				}
+				value, ok := typed[step.field]
+				if ok && !step.isIndex {
+					next = append(next, value)
+				}
+			case []any:
+				switch {
+				case step.wildcard:
+					next = append(next, typed...)
+				case step.isIndex:
+					index := step.index
+					if index < 0 {
+						index += len(typed)
+					}
+					if index >= 0 && index < len(typed) {
+						next = append(next, typed[index])
+					}
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+func writeJSONPathValue(buffer *strings.Builder, value any) error {
+	if text, ok := value.(string); ok {
+		buffer.WriteString(text)
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSONPath result: %w", err)
+	}
+	buffer.Write(data)
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestJSONPath(t *testing.T) {
+	object, err := structpb.NewStruct(map[string]any{
+		"id": "123",
+		"status": map[string]any{
+			"api_url": "https://api.example.com",
+		},
+		"parameters": []any{
+			map[string]any{"name": "a", "value": 1},
+			map[string]any{"name": "b", "value": 2},
+		},
+		"labels": map[string]any{
+			"x.y/z": "w",
+			"}":     "brace",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		template string
+		expected string
+		err      bool
+	}{
+		{template: "{.id}", expected: "123"},
+		{template: "id={.id}\n", expected: "id=123\n"},
+		{template: "{$.status.api_url}", expected: "https://api.example.com"},
+		{template: "{.parameters[0].name}", expected: "a"},
+		{template: "{.parameters[-1].name}", expected: "b"},
+		{template: "{.parameters[*].name}", expected: "a b"},
+		{template: "{.parameters[5].name}", expected: ""},
+		{template: "{.missing}", expected: ""},
+		{template: "{.parameters[0]}", expected: `{"name":"a","value":1}`},
+		{template: "{.labels['x.y/z']}", expected: "w"},
+		{template: "{.labels['}']}", expected: "brace"},
+		{template: "{.labels[\"}\"]}", expected: "brace"},
+		{template: "{.id}{\"\\n\"}", expected: "123\n"},
+		{template: "{\"}\"}", expected: "}"},
+		{template: "{.id}{'}'}", expected: "123}"},
+		{template: "{'a]b'}", expected: "a]b"},
+		{template: "{'\\'quoted\\''}", expected: "'quoted'"},
+		{template: "{'say \"hi\"'}", expected: "say \"hi\""},
+		{template: "{\"it's\"}", expected: "it's"},
+		{template: "{'a\\nb'}", expected: "a\nb"},
+		{template: "{.id", err: true},
+		{template: "{\"}", err: true},
+		{template: "{'a'b'}", err: true},
+		{template: "{id}", err: true},
+		{template: "{.parameters[x]}", err: true},
+		{template: "{.parameters[0}", err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			buffer := &strings.Builder{}
+			err := JSONPath(buffer, test.template, object)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected an error, but got '%s'", buffer.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buffer.String() != test.expected {
+				t.Fatalf("expected '%s', but got '%s'", test.expected, buffer.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Identified is the interface implemented by the API objects that have an identifier.
+type Identified interface {
+	proto.Message
+	GetId() string
+}
+
+// Name writes the type and identifier of an object in one line, like 'cluster/123', so that the output can be
+// processed with tools like 'xargs'.
+func Name(writer io.Writer, kind, id string) error {
+	_, err := fmt.Fprintf(writer, "%s/%s\n", kind, id)
+	return err
+}
+
+// Names writes the type and identifier of each object using the Name function. The registered processors are applied
+// to the objects before writing them, so that for example anonymized identifiers are used.
+func Names[T Identified](writer io.Writer, kind string, objects []T) error {
+	for _, object := range objects {
+		object, err := Process(object)
+		if err != nil {
+			return err
+		}
+		err = Name(writer, kind, object.GetId())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseName extracts the identifier from a text written by the Name function, like 'cluster/123'. Texts without a type
+// are assumed to be plain identifiers. It returns an error if the text has a type different to the given kind, or to
+// its plural.
+func ParseName(kind, text string) (id string, err error) {
+	prefix, suffix, found := strings.Cut(text, "/")
+	if !found {
+		id = text
+		return
+	}
+	if prefix != kind && prefix != kind+"s" {
+		err = fmt.Errorf("'%s' isn't a %s, its type is '%s'", text, kind, prefix)
+		return
+	}
+	id = suffix
+	return
+}
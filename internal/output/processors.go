@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Processor is the interface that should be implemented by the stages that modify the objects before they are
+// displayed, for example to mask sensitive values or to add annotations that are specific to an organization.
+//
+// This is intended for distributions of the tool that need additional processing: they can register their processors
+// from an 'init' function of a file added to the main package, without changing the code that renders the objects:
+//
+//	func init() {
+//		output.RegisterProcessor("mask", output.ProcessorFunc(func(object proto.Message) error {
+//			...
+//		}))
+//	}
+type Processor interface {
+	// Process receives a copy of the object that is about to be displayed, and can modify it in place.
+	Process(object proto.Message) error
+}
+
+// ProcessorFunc is an adapter that allows the use of ordinary functions as processors.
+type ProcessorFunc func(object proto.Message) error
+
+// Process is the implementation of the Processor interface.
+func (f ProcessorFunc) Process(object proto.Message) error {
+	return f(object)
+}
+
+// namedProcessor is a registered processor together with its name.
+type namedProcessor struct {
+	name      string
+	processor Processor
+}
+
+var (
+	processorsLock sync.RWMutex
+	processors     []namedProcessor
+)
+
+// RegisterProcessor adds a processor that will be applied to all the objects before they are displayed. Processors
+// are applied in the order they were registered. It panics if the name is already registered, as that is a
+// programming error.
+func RegisterProcessor(name string, processor Processor) {
+	processorsLock.Lock()
+	defer processorsLock.Unlock()
+	for _, existing := range processors {
+		if existing.name == name {
+			panic(fmt.Sprintf("output processor '%s' is already registered", name))
+		}
+	}
+	processors = append(processors, namedProcessor{
+		name:      name,
+		processor: processor,
+	})
+}
+
+// Process applies the registered processors to a copy of the given object, and returns that copy. When there are no
+// processors the object is returned as is, without copying it.
+func Process[T proto.Message](object T) (result T, err error) {
+	processorsLock.RLock()
+	defer processorsLock.RUnlock()
+	if len(processors) == 0 {
+		result = object
+		return
+	}
+	result = proto.Clone(object).(T)
+	for _, item := range processors {
+		err = item.processor.Process(result)
+		if err != nil {
+			err = fmt.Errorf("output processor '%s' failed: %w", item.name, err)
+			return
+		}
+	}
+	return
+}
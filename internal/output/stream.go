@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/innabox/fulfillment-cli/internal/paging"
+)
+
+// Stream retrieves the objects one page at a time and writes them to the writer as soon as each page is received, so
+// that the memory used doesn't grow with the number of objects. The format can be 'json', to use the JSON Lines
+// format, or 'yaml', to write each object as a separate YAML document. The visit function, if not nil, is called for
+// each object before writing it.
+func Stream[T proto.Message](ctx context.Context, writer io.Writer, pager *paging.Pager[T], format string,
+	visit func(T)) error {
+	var write func(io.Writer, proto.Message) error
+	switch format {
+	case "json":
+		write = JSONLine
+	case "yaml":
+		write = YAMLDocument
+	default:
+		return fmt.Errorf("output format '%s' can't be streamed, it should be 'json' or 'yaml'", format)
+	}
+	buffered := bufio.NewWriter(writer)
+	for {
+		items, err := pager.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return buffered.Flush()
+		}
+		for _, item := range items {
+			if visit != nil {
+				visit(item)
+			}
+			err = write(buffered, item)
+			if err != nil {
+				return fmt.Errorf("failed to write object: %w", err)
+			}
+		}
+		err = buffered.Flush()
+		if err != nil {
+			return err
+		}
+	}
+}
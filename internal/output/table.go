@@ -0,0 +1,320 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// columnPadding is the number of spaces added between columns.
+const columnPadding = 2
+
+// minTruncatedWidth is the width below which columns aren't truncated to fit the table in the maximum width, as
+// shorter values would no longer be useful.
+const minTruncatedWidth = 8
+
+// ellipsis is the text added at the end of values that are truncated because they are longer than the maximum width
+// of the column.
+const ellipsis = "…"
+
+// Align is the type of the alignment of the values of a column.
+type Align int
+
+const (
+	// AlignLeft aligns values to the left. This is the default.
+	AlignLeft Align = iota
+
+	// AlignRight aligns values to the right. This is intended for numeric columns.
+	AlignRight
+)
+
+// Column describes a column of a table.
+type Column struct {
+	// Header is the text displayed in the header of the column.
+	Header string
+
+	// Width is the minimum width of the column. The column will be wider if the values don't fit.
+	Width int
+
+	// Max is the maximum width of the column. Values longer than this will be truncated. Zero means no limit.
+	Max int
+
+	// Align is the alignment of the values.
+	Align Align
+
+	// Wide indicates that the column is only displayed when the wide output format is requested.
+	Wide bool
+}
+
+// FindColumn returns the index of the column with the given header, or -1 if there is no such column. The comparison
+// ignores case and considers underscores equivalent to spaces, so that 'api_url' matches 'API URL'.
+func FindColumn(columns []Column, header string) int {
+	normalized := normalizeHeader(header)
+	for i, column := range columns {
+		if normalizeHeader(column.Header) == normalized {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeHeader(header string) string {
+	return strings.ToUpper(strings.ReplaceAll(header, "_", " "))
+}
+
+// Table writes rows of text aligned in columns. Unlike the tabwriter package it remembers the width of the columns,
+// so that more rows can be added later, for example when watching for changes, without breaking the alignment.
+type Table struct {
+	writer     io.Writer
+	columns    []Column
+	wide       bool
+	noHeaders  bool
+	noTruncate bool
+	maxWidth   int
+	highlight  func(string) string
+	shown      []Column
+	indexes    []int
+	widths     []int
+	limits     []int
+}
+
+// NewTable creates a table that writes to the given writer and has the given columns.
+func NewTable(writer io.Writer, columns ...Column) *Table {
+	return &Table{
+		writer:  writer,
+		columns: columns,
+	}
+}
+
+// SetWide sets the flag that indicates if the columns marked as wide should be displayed. This must be called before
+// writing any row. Rows always contain the values for all the columns, and the values of the wide columns are
+// discarded when this flag isn't set.
+func (t *Table) SetWide(wide bool) {
+	t.wide = wide
+}
+
+// SetNoHeaders sets the flag that indicates that the headers shouldn't be written. In that case, if there is only one
+// column and one row, the value is written as is, without truncating it, so that it can be used easily in scripts.
+func (t *Table) SetNoHeaders(noHeaders bool) {
+	t.noHeaders = noHeaders
+}
+
+// SetNoTruncate sets the flag that indicates that values should be written completely, even if they are longer than
+// the maximum width of the column or than the maximum width of the table. This must be called before writing any
+// row.
+func (t *Table) SetNoTruncate(noTruncate bool) {
+	t.noTruncate = noTruncate
+}
+
+// SetMaxWidth sets the maximum width of the lines of the table, usually the width of the terminal. When the columns
+// don't fit the widest ones are truncated, but not below a minimum width, so lines may still be longer if there are
+// many columns. Zero means no limit. This must be called before writing any row.
+func (t *Table) SetMaxWidth(width int) {
+	t.maxWidth = width
+}
+
+// SetHighlight sets the function that is used to highlight the values passed to AppendHighlighted, for example
+// adding the escape sequences that make the terminal display them in bold.
+func (t *Table) SetHighlight(highlight func(string) string) {
+	t.highlight = highlight
+}
+
+// Write writes the headers and the given rows, calculating the width of the columns so that all the values fit.
+func (t *Table) Write(rows [][]string) {
+	t.shown = nil
+	t.indexes = nil
+	for i, column := range t.columns {
+		if column.Wide && !t.wide {
+			continue
+		}
+		t.shown = append(t.shown, column)
+		t.indexes = append(t.indexes, i)
+	}
+	t.widths = make([]int, len(t.shown))
+	t.limits = make([]int, len(t.shown))
+	for i, column := range t.shown {
+		t.widths[i] = column.Width
+	}
+	headers := make([]string, len(t.shown))
+	for i, column := range t.shown {
+		headers[i] = column.Header
+	}
+	rows = t.visible(rows...)
+	if t.noHeaders && len(t.shown) == 1 && len(rows) == 1 {
+		fmt.Fprintf(t.writer, "%s\n", rows[0][0])
+		return
+	}
+	if !t.noHeaders {
+		t.grow(headers)
+	}
+	for _, row := range rows {
+		t.grow(row)
+	}
+	t.fit(headers)
+	if !t.noHeaders {
+		t.line(headers, nil)
+	}
+	for _, row := range rows {
+		t.line(row, nil)
+	}
+}
+
+// Append writes an additional row. The width of the columns is preserved, unless the new values don't fit.
+func (t *Table) Append(row []string) {
+	if t.widths == nil {
+		t.Write([][]string{row})
+		return
+	}
+	row = t.visible(row)[0]
+	t.grow(row)
+	t.line(row, nil)
+}
+
+// AppendHighlighted writes an additional row like Append, highlighting the values of the columns indicated by the
+// second parameter. That is intended to show what changed since a previous version of the row. Nothing is highlighted
+// if no highlight function has been set.
+func (t *Table) AppendHighlighted(row []string, highlighted []bool) {
+	if t.widths == nil || t.highlight == nil {
+		t.Append(row)
+		return
+	}
+	flags := make([]bool, len(t.indexes))
+	for i, index := range t.indexes {
+		flags[i] = index < len(highlighted) && highlighted[index]
+	}
+	row = t.visible(row)[0]
+	t.grow(row)
+	t.line(row, flags)
+}
+
+// Changed compares two versions of a row and returns a slice that contains true for the values that are different.
+// The result is nil if there is no previous version.
+func Changed(previous, current []string) []bool {
+	if previous == nil {
+		return nil
+	}
+	result := make([]bool, len(current))
+	for i := range current {
+		result[i] = i >= len(previous) || previous[i] != current[i]
+	}
+	return result
+}
+
+// visible removes from the rows the values of the columns that aren't displayed.
+func (t *Table) visible(rows ...[]string) [][]string {
+	result := make([][]string, len(rows))
+	for i, row := range rows {
+		values := make([]string, 0, len(t.indexes))
+		for _, index := range t.indexes {
+			value := ""
+			if index < len(row) {
+				value = row[index]
+			}
+			values = append(values, value)
+		}
+		result[i] = values
+	}
+	return result
+}
+
+func (t *Table) grow(row []string) {
+	for i, value := range row {
+		if i >= len(t.widths) {
+			t.widths = append(t.widths, 0)
+		}
+		width := utf8.RuneCountInString(t.truncate(i, value))
+		if width > t.widths[i] {
+			t.widths[i] = width
+		}
+	}
+}
+
+// fit reduces the width of the widest columns till the table fits in the maximum width, or till all the columns have
+// the minimum width. The reduced widths are remembered as limits, so that values of rows appended later are also
+// truncated.
+func (t *Table) fit(headers []string) {
+	if t.maxWidth <= 0 || t.noTruncate || len(t.widths) == 0 {
+		return
+	}
+	total := columnPadding * (len(t.widths) - 1)
+	for _, width := range t.widths {
+		total += width
+	}
+	for total > t.maxWidth {
+		widest := -1
+		for i, width := range t.widths {
+			minimum := max(minTruncatedWidth, utf8.RuneCountInString(headers[i]))
+			if width > minimum && (widest == -1 || width > t.widths[widest]) {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			return
+		}
+		t.widths[widest]--
+		t.limits[widest] = t.widths[widest]
+		total--
+	}
+}
+
+func (t *Table) line(row []string, highlighted []bool) {
+	buffer := &strings.Builder{}
+	for i, value := range row {
+		value = t.truncate(i, value)
+		padding := t.widths[i] - utf8.RuneCountInString(value)
+		if i < len(highlighted) && highlighted[i] {
+			value = t.highlight(value)
+		}
+		if t.align(i) == AlignRight {
+			buffer.WriteString(strings.Repeat(" ", padding))
+			buffer.WriteString(value)
+		} else {
+			buffer.WriteString(value)
+			if i < len(row)-1 {
+				buffer.WriteString(strings.Repeat(" ", padding))
+			}
+		}
+		if i < len(row)-1 {
+			buffer.WriteString(strings.Repeat(" ", columnPadding))
+		}
+	}
+	fmt.Fprintf(t.writer, "%s\n", buffer.String())
+}
+
+func (t *Table) align(i int) Align {
+	if i < len(t.shown) {
+		return t.shown[i].Align
+	}
+	return AlignLeft
+}
+
+// truncate truncates the value so that it isn't longer than the maximum width of the column, or than the width that
+// the column was reduced to in order to fit the table in the maximum width.
+func (t *Table) truncate(i int, value string) string {
+	if i >= len(t.shown) || t.noTruncate {
+		return value
+	}
+	max := t.shown[i].Max
+	if i < len(t.limits) && t.limits[i] > 0 && (max <= 0 || t.limits[i] < max) {
+		max = t.limits[i]
+	}
+	if max <= 0 || utf8.RuneCountInString(value) <= max {
+		return value
+	}
+	runes := []rune(value)
+	return string(runes[:max-utf8.RuneCountInString(ellipsis)]) + ellipsis
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// TableJSONVersion is the version of the schema written by the TableJSON function. It will only be incremented when
+// changes that aren't backwards compatible are made, like removing or renaming fields.
+const TableJSONVersion = 1
+
+// Cell is a value of a table, including the raw value and its type in addition to the text that would be displayed.
+type Cell struct {
+	// Text is the value as it is displayed in the table, before truncating it.
+	Text string `json:"text"`
+
+	// Value is the raw value, using the generic JSON representation. It is null if the value is null.
+	Value any `json:"value"`
+
+	// Type is the name of the type of the value. For columns calculated with CEL expressions it is the name of the
+	// CEL type, for example 'string', 'int' or 'google.protobuf.Timestamp'. For the rest of the columns it is
+	// 'string'.
+	Type string `json:"type"`
+}
+
+// TextCells converts text values into cells of type 'string'.
+func TextCells(values []string) []Cell {
+	result := make([]Cell, len(values))
+	for i, value := range values {
+		result[i] = Cell{
+			Text:  value,
+			Value: value,
+			Type:  "string",
+		}
+	}
+	return result
+}
+
+type tableJSON struct {
+	Version int          `json:"version"`
+	Columns []columnJSON `json:"columns"`
+	Rows    []rowJSON    `json:"rows"`
+}
+
+type columnJSON struct {
+	Header string `json:"header"`
+	Align  string `json:"align"`
+	Wide   bool   `json:"wide"`
+}
+
+type rowJSON struct {
+	Cells []Cell `json:"cells"`
+}
+
+// TableJSON writes the model of a table, the columns and the cells of the rows, as indented JSON. This is intended for
+// tools that want to display the same columns than the CLI without reimplementing them. All the columns are included,
+// and the ones that are only displayed with the wide format are marked as such.
+func TableJSON(writer io.Writer, columns []Column, rows [][]Cell) error {
+	model := tableJSON{
+		Version: TableJSONVersion,
+		Columns: make([]columnJSON, len(columns)),
+		Rows:    make([]rowJSON, len(rows)),
+	}
+	for i, column := range columns {
+		align := "left"
+		if column.Align == AlignRight {
+			align = "right"
+		}
+		model.Columns[i] = columnJSON{
+			Header: column.Header,
+			Align:  align,
+			Wide:   column.Wide,
+		}
+	}
+	for i, row := range rows {
+		model.Rows[i] = rowJSON{
+			Cells: row,
+		}
+	}
+	data, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal table: %w", err)
+	}
+	fmt.Fprintf(writer, "%s\n", data)
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// GoTemplate executes the given Go template for each object, using the JSON representation of the object as the
+// data, and writes the results to the writer. The input can be a protocol buffers message or a slice of messages.
+// Nothing is added between the results, so the template should contain the line breaks if they are needed, for
+// example '{{.id}}{{"\n"}}'.
+func GoTemplate(writer io.Writer, text string, input any) error {
+	tmpl, err := template.New("output").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template '%s': %w", text, err)
+	}
+	objects, err := toObjects(input)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		err = tmpl.Execute(writer, object)
+		if err != nil {
+			return fmt.Errorf("failed to execute template '%s': %w", text, err)
+		}
+	}
+	return nil
+}
+
+// toObjects converts a message, or a slice of messages, into a slice containing the generic JSON representation of
+// each message.
+func toObjects(input any) (result []any, err error) {
+	value, err := toValue(input)
+	if err != nil {
+		return
+	}
+	items, ok := value.([]any)
+	if ok {
+		result = items
+	} else {
+		result = []any{value}
+	}
+	return
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Formats of timestamps supported by the SetTimestampFormat function:
+const (
+	// TimestampsLocal uses the RFC 3339 format in the local time zone. This is the default.
+	TimestampsLocal = "local"
+
+	// TimestampsUTC uses the RFC 3339 format in UTC.
+	TimestampsUTC = "utc"
+
+	// TimestampsUnix uses the number of seconds since the Unix epoch.
+	TimestampsUnix = "unix"
+
+	// TimestampsRelative uses the time relative to now, for example '5m ago'.
+	TimestampsRelative = "relative"
+)
+
+// TimestampFormats contains the names of the supported formats of timestamps.
+var TimestampFormats = []string{
+	TimestampsLocal,
+	TimestampsUTC,
+	TimestampsUnix,
+	TimestampsRelative,
+}
+
+// timestampFormat is the format used by the Timestamp and Time functions.
+var timestampFormat = TimestampsLocal
+
+// SetTimestampFormat sets the format used by the Timestamp and Time functions. This is intended for the
+// '--timestamps' command line flag.
+func SetTimestampFormat(format string) error {
+	for _, supported := range TimestampFormats {
+		if format == supported {
+			timestampFormat = format
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"timestamp format '%s' isn't supported, it should be one of '%s'",
+		format, strings.Join(TimestampFormats, "', '"),
+	)
+}
+
+// Timestamp returns the text representation of the given timestamp, using the format selected with the
+// SetTimestampFormat function. Timestamps that aren't set are represented with a dash.
+func Timestamp(value *timestamppb.Timestamp) string {
+	if value == nil {
+		return "-"
+	}
+	return Time(value.AsTime())
+}
+
+// Time returns the text representation of the given time, using the format selected with the SetTimestampFormat
+// function.
+func Time(value time.Time) string {
+	switch timestampFormat {
+	case TimestampsUTC:
+		return value.UTC().Format(time.RFC3339)
+	case TimestampsUnix:
+		return strconv.FormatInt(value.Unix(), 10)
+	case TimestampsRelative:
+		return relative(time.Since(value))
+	default:
+		return value.Local().Format(time.RFC3339)
+	}
+}
+
+// relative returns a short text that describes the given elapsed time, for example '5m ago' or 'in 2h' if it is
+// negative.
+func relative(elapsed time.Duration) string {
+	future := elapsed < 0
+	if future {
+		elapsed = -elapsed
+	}
+	var text string
+	switch {
+	case elapsed < time.Minute:
+		text = fmt.Sprintf("%ds", int(elapsed/time.Second))
+	case elapsed < time.Hour:
+		text = fmt.Sprintf("%dm", int(elapsed/time.Minute))
+	case elapsed < 48*time.Hour:
+		text = fmt.Sprintf("%dh", int(elapsed/time.Hour))
+	default:
+		text = fmt.Sprintf("%dd", int(elapsed/(24*time.Hour)))
+	}
+	if future {
+		return "in " + text
+	}
+	return text + " ago"
+}
+
+// Age returns the time elapsed since the given timestamp in a short format, for example '3d2h'. Timestamps that aren't
+// set are represented with a dash.
+func Age(value *timestamppb.Timestamp) string {
+	if value == nil {
+		return "-"
+	}
+	return Duration(time.Since(value.AsTime()))
+}
+
+// Duration returns a short text that describes the given duration using at most two units, for example '3d2h',
+// '5h10m', '4m30s' or '12s'. Negative durations are preceded by a minus sign.
+func Duration(value time.Duration) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	days := int64(value / (24 * time.Hour))
+	hours := int64(value/time.Hour) % 24
+	minutes := int64(value/time.Minute) % 60
+	seconds := int64(value/time.Second) % 60
+	var text string
+	switch {
+	case days > 0:
+		text = pair(days, "d", hours, "h")
+	case hours > 0:
+		text = pair(hours, "h", minutes, "m")
+	case minutes > 0:
+		text = pair(minutes, "m", seconds, "s")
+	default:
+		text = fmt.Sprintf("%ds", seconds)
+	}
+	return sign + text
+}
+
+// pair formats a pair of quantities with their units, omitting the second one when it is zero.
+func pair(first int64, firstUnit string, second int64, secondUnit string) string {
+	if second == 0 {
+		return fmt.Sprintf("%d%s", first, firstUnit)
+	}
+	return fmt.Sprintf("%d%s%d%s", first, firstUnit, second, secondUnit)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// YAML writes the YAML representation of the message, or slice of messages, to the writer.
+func YAML(writer io.Writer, input any) error {
+	value, err := toValue(input)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// YAMLDocument writes the YAML representation of the message as a separate document, preceded by the '---' separator,
+// so that multiple messages can be written to the same stream.
+func YAMLDocument(writer io.Writer, message proto.Message) error {
+	_, err := io.WriteString(writer, "---\n")
+	if err != nil {
+		return err
+	}
+	return YAML(writer, message)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package paging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultConcurrency is the number of objects retrieved simultaneously by the GetAll function when no other value is
+// specified.
+const DefaultConcurrency = 8
+
+// GetFunc is the type of the functions that retrieve one object given its identifier.
+type GetFunc[T any] func(ctx context.Context, id string) (item T, err error)
+
+// GetAll retrieves the objects with the given identifiers, using at most the given number of simultaneous requests.
+// The items are returned in the same order than the identifiers. When one of the requests fails the rest are
+// cancelled and the error of the first one that failed is returned. If the concurrency is zero or negative the default
+// concurrency will be used.
+func GetAll[T any](ctx context.Context, ids []string, concurrency int, get GetFunc[T]) (items []T, err error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make([]T, len(ids))
+	var failure error
+	var once sync.Once
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				item, err := get(ctx, ids[i])
+				if err != nil {
+					once.Do(func() {
+						failure = fmt.Errorf("failed to get '%s': %w", ids[i], err)
+						cancel()
+					})
+					continue
+				}
+				results[i] = item
+			}
+		}()
+	}
+send:
+	for i := range ids {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if failure != nil {
+		err = failure
+		return
+	}
+	if ctx.Err() != nil {
+		err = ctx.Err()
+		return
+	}
+	items = results
+	return
+}
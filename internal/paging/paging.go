@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package paging contains helpers to retrieve long lists of objects from the server using multiple requests, each
+// of them retrieving one page of results via the 'offset' and 'limit' fields of the list requests.
+package paging
+
+import (
+	"context"
+)
+
+// DefaultSize is the number of items requested in each page when no other size is specified.
+const DefaultSize = 100
+
+// FetchFunc is the type of the functions that retrieve one page of items. They receive the offset and the maximum
+// number of items to return, and should return the items and the value of the 'total' field of the response, or
+// zero if the server didn't return it.
+type FetchFunc[T any] func(ctx context.Context, offset, limit int32) (items []T, total int32, err error)
+
+// Pager retrieves the items of a list one page at a time. Don't create instances of this type directly, use the
+// NewPager function instead.
+type Pager[T any] struct {
+	fetch  FetchFunc[T]
+	size   int32
+	offset int32
+	done   bool
+}
+
+// NewPager creates a pager that uses the given function to retrieve pages of the given size. If the size is zero
+// or negative the default size will be used.
+func NewPager[T any](fetch FetchFunc[T], size int32) *Pager[T] {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &Pager[T]{
+		fetch: fetch,
+		size:  size,
+	}
+}
+
+// Next retrieves the next page of items. It returns nil when there are no more items.
+func (p *Pager[T]) Next(ctx context.Context) (items []T, err error) {
+	if p.done {
+		return
+	}
+	items, total, err := p.fetch(ctx, p.offset, p.size)
+	if err != nil {
+		return
+	}
+	count := int32(len(items))
+	p.offset += count
+	switch {
+	case count == 0:
+		// There are no more items.
+		p.done = true
+	case count > p.size:
+		// The server ignored the limit and returned everything, requesting more pages could return the same items
+		// again.
+		p.done = true
+	case total > 0:
+		// The server may return fewer items than requested, so when it reported the total we continue till we
+		// have all of them.
+		p.done = p.offset >= total
+	case count < p.size:
+		// Without the total a short page is the only indication that this is the last one.
+		p.done = true
+	}
+	return
+}
+
+// ListAll retrieves all the items of a list, using as many requests as needed.
+func ListAll[T any](ctx context.Context, fetch FetchFunc[T]) (result []T, err error) {
+	pager := NewPager(fetch, DefaultSize)
+	for {
+		var items []T
+		items, err = pager.Next(ctx)
+		if err != nil || len(items) == 0 {
+			return
+		}
+		result = append(result, items...)
+	}
+}
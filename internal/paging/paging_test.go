@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package paging
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// fakeServer simulates the list method of a server that contains the given number of items, and that returns at most
+// 'max' items in each page, regardless of the requested limit.
+type fakeServer struct {
+	items     int32
+	max       int32
+	withTotal bool
+}
+
+func (s *fakeServer) fetch(ctx context.Context, offset, limit int32) (items []int32, total int32, err error) {
+	count := min(limit, s.max, max(s.items-offset, 0))
+	for i := int32(0); i < count; i++ {
+		items = append(items, offset+i)
+	}
+	if s.withTotal {
+		total = s.items
+	}
+	return
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int32
+		max       int32
+		withTotal bool
+		expected  int32
+	}{
+		{name: "Empty", items: 0, max: 1000, withTotal: true, expected: 0},
+		{name: "Single page", items: 10, max: 1000, withTotal: true, expected: 10},
+		{name: "Exact pages", items: 2 * DefaultSize, max: 1000, withTotal: true, expected: 2 * DefaultSize},
+		{name: "Several pages", items: 250, max: 1000, withTotal: true, expected: 250},
+		{name: "Short pages with total", items: 250, max: 30, withTotal: true, expected: 250},
+		{name: "Short pages without total", items: 250, max: 30, withTotal: false, expected: 30},
+		{name: "Several pages without total", items: 250, max: 1000, withTotal: false, expected: 250},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeServer{
+				items:     test.items,
+				max:       test.max,
+				withTotal: test.withTotal,
+			}
+			items, err := ListAll(context.Background(), server.fetch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if int32(len(items)) != test.expected {
+				t.Fatalf("expected %d items, but got %d", test.expected, len(items))
+			}
+			for i, item := range items {
+				if item != int32(i) {
+					t.Fatalf("expected item %d to be %d, but it is %d", i, i, item)
+				}
+			}
+		})
+	}
+}
+
+func TestPagerStopsWhenServerIgnoresLimit(t *testing.T) {
+	calls := 0
+	fetch := func(ctx context.Context, offset, limit int32) ([]int32, int32, error) {
+		calls++
+		return []int32{1, 2, 3, 4, 5}, 10, nil
+	}
+	pager := NewPager(fetch, 2)
+	var all []int32
+	for {
+		items, err := pager.Next(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(items) == 0 {
+			break
+		}
+		all = append(all, items...)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one call, but got %d", calls)
+	}
+	if !slices.Equal(all, []int32{1, 2, 3, 4, 5}) {
+		t.Fatalf("unexpected items %v", all)
+	}
+}
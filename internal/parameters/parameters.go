@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package parameters contains functions to convert the values of template parameters given in the command line to the
+// types declared in the templates.
+package parameters
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+)
+
+// typePrefix is the prefix of the type URLs used to declare the types of the parameters.
+const typePrefix = "type.googleapis.com/"
+
+// Parse converts the given text to the type of the given parameter definition, and returns it wrapped in an Any, as
+// required by the 'template_parameters' field of the order.
+func Parse(definition *fulfillmentv1.ClusterTemplateParameterDefinition, text string) (result *anypb.Any, err error) {
+	var value proto.Message
+	typeName := strings.TrimPrefix(definition.Type, typePrefix)
+	switch typeName {
+	case "google.protobuf.BoolValue":
+		var parsed bool
+		parsed, err = strconv.ParseBool(text)
+		value = wrapperspb.Bool(parsed)
+	case "google.protobuf.Int32Value":
+		var parsed int64
+		parsed, err = strconv.ParseInt(text, 10, 32)
+		value = wrapperspb.Int32(int32(parsed))
+	case "google.protobuf.Int64Value":
+		var parsed int64
+		parsed, err = strconv.ParseInt(text, 10, 64)
+		value = wrapperspb.Int64(parsed)
+	case "google.protobuf.FloatValue":
+		var parsed float64
+		parsed, err = strconv.ParseFloat(text, 32)
+		value = wrapperspb.Float(float32(parsed))
+	case "google.protobuf.DoubleValue":
+		var parsed float64
+		parsed, err = strconv.ParseFloat(text, 64)
+		value = wrapperspb.Double(parsed)
+	case "google.protobuf.StringValue":
+		value = wrapperspb.String(text)
+	case "google.protobuf.BytesValue":
+		value = wrapperspb.Bytes([]byte(text))
+	case "google.protobuf.Timestamp":
+		var parsed time.Time
+		parsed, err = time.Parse(time.RFC3339, text)
+		value = timestamppb.New(parsed)
+	case "google.protobuf.Duration":
+		var parsed time.Duration
+		parsed, err = time.ParseDuration(text)
+		value = durationpb.New(parsed)
+	case "google.protobuf.Value":
+		parsed := &structpb.Value{}
+		err = protojson.Unmarshal([]byte(text), parsed)
+		value = parsed
+	default:
+		err = fmt.Errorf(
+			"parameter '%s' has type '%s', which isn't supported",
+			definition.Name, definition.Type,
+		)
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf(
+			"value '%s' isn't valid for parameter '%s' of type '%s': %v",
+			text, definition.Name, typeName, err,
+		)
+		return
+	}
+	result, err = anypb.New(value)
+	return
+}
+
+// ParseJSON converts the given JSON text to the type of the given parameter definition, and returns it wrapped in an
+// Any. The JSON text should be the representation of the value as described in the ProtoJSON format, for example
+// '3' for an integer, '"3s"' for a duration or an arbitrary JSON document for a 'google.protobuf.Value'.
+func ParseJSON(definition *fulfillmentv1.ClusterTemplateParameterDefinition, text string) (result *anypb.Any,
+	err error) {
+	typeName := strings.TrimPrefix(definition.Type, typePrefix)
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(typeName))
+	if err != nil {
+		err = fmt.Errorf(
+			"parameter '%s' has type '%s', which isn't supported",
+			definition.Name, definition.Type,
+		)
+		return
+	}
+	value := messageType.New().Interface()
+	err = protojson.Unmarshal([]byte(text), value)
+	if err != nil {
+		err = fmt.Errorf(
+			"JSON value '%s' isn't valid for parameter '%s' of type '%s': %v",
+			text, definition.Name, typeName, err,
+		)
+		return
+	}
+	result, err = anypb.New(value)
+	return
+}
+
+// Find returns the definition of the parameter of the template that has the given name, or an error explaining what
+// are the valid names if there is no such parameter.
+func Find(template *fulfillmentv1.ClusterTemplate, name string) (result *fulfillmentv1.ClusterTemplateParameterDefinition,
+	err error) {
+	for _, definition := range template.Parameters {
+		if definition.Name == name {
+			result = definition
+			return
+		}
+	}
+	names := make([]string, len(template.Parameters))
+	for i, definition := range template.Parameters {
+		names[i] = fmt.Sprintf("'%s'", definition.Name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		err = fmt.Errorf("template '%s' doesn't have parameters, so '%s' can't be used", template.Id, name)
+		return
+	}
+	err = fmt.Errorf(
+		"template '%s' doesn't have a parameter named '%s', valid parameters are %s",
+		template.Id, name, strings.Join(names, ", "),
+	)
+	return
+}
+
+// CheckRequired checks that the given values contain all the required parameters of the template.
+func CheckRequired(template *fulfillmentv1.ClusterTemplate, values map[string]*anypb.Any) error {
+	var missing []string
+	for _, definition := range template.Parameters {
+		if !definition.Required {
+			continue
+		}
+		_, ok := values[definition.Name]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("'%s'", definition.Name))
+		}
+	}
+	switch len(missing) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("parameter %s of template '%s' is required", missing[0], template.Id)
+	default:
+		return fmt.Errorf(
+			"parameters %s of template '%s' are required",
+			strings.Join(missing, ", "), template.Id,
+		)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package patch contains functions to apply JSON merge patches, as described in RFC 7386, to API objects.
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sigs.k8s.io/yaml"
+)
+
+// Parse parses a merge patch from its JSON or YAML representation. The patch must be an object.
+func Parse(data []byte) (result map[string]any, err error) {
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		err = fmt.Errorf("patch should be an object: %v", err)
+		return
+	}
+	if result == nil {
+		err = fmt.Errorf("patch should be an object, but it is null")
+	}
+	return
+}
+
+// Apply applies the merge patch to a copy of the message. It returns the result and the paths of the fields that the
+// patch changes, sorted alphabetically, so that they can be used as the 'update_mask' of update requests. Fields
+// mentioned in the patch that keep their current value aren't included, so the paths are empty when the patch doesn't
+// change anything. The paths stop at maps, lists and well known types, as those are replaced completely by the patch.
+func Apply(message proto.Message, patch map[string]any) (result proto.Message, paths []string, err error) {
+	patch, paths, err = normalize(message.ProtoReflect().Descriptor(), "", patch)
+	if err != nil {
+		return
+	}
+	sort.Strings(paths)
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return
+	}
+	var object any
+	err = json.Unmarshal(data, &object)
+	if err != nil {
+		return
+	}
+	object = Merge(object, patch)
+	data, err = json.Marshal(object)
+	if err != nil {
+		return
+	}
+	result = message.ProtoReflect().New().Interface()
+	err = protojson.Unmarshal(data, result)
+	if err != nil {
+		err = fmt.Errorf("failed to apply patch: %v", err)
+		result = nil
+		paths = nil
+		return
+	}
+	paths = slices.DeleteFunc(paths, func(path string) bool {
+		return !changed(message.ProtoReflect(), result.ProtoReflect(), path)
+	})
+	return
+}
+
+// changed checks if the value of the field with the given path is different in the two messages.
+func changed(before, after protoreflect.Message, path string) bool {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		field := before.Descriptor().Fields().ByName(protoreflect.Name(name))
+		before = before.Get(field).Message()
+		after = after.Get(field).Message()
+	}
+	field := before.Descriptor().Fields().ByName(protoreflect.Name(names[len(names)-1]))
+	if field.HasPresence() && before.Has(field) != after.Has(field) {
+		return true
+	}
+	return !before.Get(field).Equal(after.Get(field))
+}
+
+// Merge applies the merge patch to the target value and returns the result, following the algorithm described in RFC
+// 7386. The target may be modified.
+func Merge(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = Merge(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// normalize replaces the JSON names of the fields used in the patch with the names used in the protocol buffers
+// definitions, as those are the names used to apply it, and calculates the paths of the fields changed by the patch.
+func normalize(descriptor protoreflect.MessageDescriptor, prefix string, patch map[string]any) (result map[string]any,
+	paths []string, err error) {
+	result = map[string]any{}
+	for name, value := range patch {
+		fields := descriptor.Fields()
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil {
+			field = fields.ByJSONName(name)
+		}
+		if field == nil {
+			err = fmt.Errorf("type '%s' doesn't have a field named '%s'", descriptor.FullName(), name)
+			return
+		}
+		if _, ok := result[string(field.Name())]; ok {
+			err = fmt.Errorf("field '%s' of type '%s' is used twice", field.Name(), descriptor.FullName())
+			return
+		}
+		path := prefix + string(field.Name())
+		nested, ok := value.(map[string]any)
+		if ok && field.Kind() == protoreflect.MessageKind && !field.IsMap() && !field.IsList() &&
+			!strings.HasPrefix(string(field.Message().FullName()), "google.protobuf.") {
+			var nestedPaths []string
+			value, nestedPaths, err = normalize(field.Message(), path+".", nested)
+			if err != nil {
+				return
+			}
+			paths = append(paths, nestedPaths...)
+		} else {
+			paths = append(paths, path)
+		}
+		result[string(field.Name())] = value
+	}
+	return
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-cli/internal/api/fulfillment/v1"
+)
+
+func TestMerge(t *testing.T) {
+	// These are the examples from appendix A of RFC 7386:
+	tests := []struct {
+		target   string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.target+" "+test.patch, func(t *testing.T) {
+			var target, patch, expected any
+			for _, item := range []struct {
+				text  string
+				value *any
+			}{{test.target, &target}, {test.patch, &patch}, {test.expected, &expected}} {
+				err := json.Unmarshal([]byte(item.text), item.value)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			actual := Merge(target, patch)
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("expected %v, but got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	value, err := anypb.New(wrapperspb.Int32(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := &fulfillmentv1.ClusterOrder{
+		Id: "123",
+		Spec: &fulfillmentv1.ClusterOrderSpec{
+			TemplateId: "ocp-small",
+			TemplateParameters: map[string]*anypb.Any{
+				"node_count": value,
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		patch    string
+		template string
+		params   int
+		paths    []string
+		err      bool
+	}{
+		{
+			name:     "Proto name",
+			patch:    `{"spec":{"template_id":"ocp-large"}}`,
+			template: "ocp-large",
+			params:   1,
+			paths:    []string{"spec.template_id"},
+		},
+		{
+			name:     "JSON name in YAML",
+			patch:    "spec:\n  templateId: ocp-large\n",
+			template: "ocp-large",
+			params:   1,
+			paths:    []string{"spec.template_id"},
+		},
+		{
+			name:     "Map is replaced",
+			patch:    `{"spec":{"template_parameters":null}}`,
+			template: "ocp-small",
+			params:   0,
+			paths:    []string{"spec.template_parameters"},
+		},
+		{
+			name: "Unchanged values",
+			patch: `{"id":"123","spec":{"template_id":"ocp-small","template_parameters":{"node_count":` +
+				`{"@type":"type.googleapis.com/google.protobuf.Int32Value","value":3}}}}`,
+			template: "ocp-small",
+			params:   1,
+			paths:    []string{},
+		},
+		{
+			name:     "Only changed values",
+			patch:    `{"id":"123","spec":{"template_id":"ocp-large"}}`,
+			template: "ocp-large",
+			params:   1,
+			paths:    []string{"spec.template_id"},
+		},
+		{
+			name:  "Unknown field",
+			patch: `{"spec":{"junk":1}}`,
+			err:   true,
+		},
+		{
+			name:  "Both names",
+			patch: `{"spec":{"template_id":"a","templateId":"b"}}`,
+			err:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := Parse([]byte(test.patch))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			result, paths, err := Apply(order, changes)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			patched := result.(*fulfillmentv1.ClusterOrder)
+			if patched.Id != "123" || patched.GetSpec().GetTemplateId() != test.template {
+				t.Fatalf("unexpected result %v", patched)
+			}
+			if len(patched.GetSpec().GetTemplateParameters()) != test.params {
+				t.Fatalf("expected %d parameters, but got %v", test.params, patched.Spec.TemplateParameters)
+			}
+			if !reflect.DeepEqual(paths, test.paths) {
+				t.Fatalf("expected paths %v, but got %v", test.paths, paths)
+			}
+			if order.Spec.TemplateId != "ocp-small" {
+				t.Fatalf("original order was modified")
+			}
+		})
+	}
+}
+
+func TestParseRejectsNonObjects(t *testing.T) {
+	for _, text := range []string{"null", "[1]", "'text'", "{"} {
+		_, err := Parse([]byte(text))
+		if err == nil {
+			t.Fatalf("expected an error for '%s'", text)
+		}
+	}
+}
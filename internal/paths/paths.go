@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package paths calculates the locations of the directories where the tool stores files. It follows the XDG base
+// directory specification, and the equivalent conventions in macOS and Windows:
+//
+//   - The configuration directory contains the files that users may want to keep, like the configuration file. It is
+//     '$XDG_CONFIG_HOME/fulfillment-cli' in Linux, '~/Library/Application Support/fulfillment-cli' in macOS and
+//     '%AppData%\fulfillment-cli' in Windows.
+//
+//   - The cache directory contains files that can be removed at any time without losing anything. It is
+//     '$XDG_CACHE_HOME/fulfillment-cli' in Linux, '~/Library/Caches/fulfillment-cli' in macOS and
+//     '%LocalAppData%\fulfillment-cli' in Windows.
+//
+//   - The state directory contains files that should persist between executions but that aren't important enough
+//     to be kept in the configuration directory, like history, snapshots and recorded sessions. It is
+//     '$XDG_STATE_HOME/fulfillment-cli' in Linux, '~/Library/Application Support/fulfillment-cli/state' in macOS and
+//     '%LocalAppData%\fulfillment-cli\state' in Windows.
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// name is the name of the sub-directory used inside the base directories.
+const name = "fulfillment-cli"
+
+// ConfigDir returns the directory where the configuration files are stored.
+func ConfigDir() (result string, err error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(base, name)
+	return
+}
+
+// CacheDir returns the directory where the cache files are stored.
+func CacheDir() (result string, err error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(base, name)
+	return
+}
+
+// StateDir returns the directory where the state files are stored.
+func StateDir() (result string, err error) {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base != "" {
+		if !filepath.IsAbs(base) {
+			err = fmt.Errorf("path in $XDG_STATE_HOME is relative")
+			return
+		}
+		result = filepath.Join(base, name)
+		return
+	}
+	switch runtime.GOOS {
+	case "windows":
+		base = os.Getenv("LocalAppData")
+		if base == "" {
+			err = fmt.Errorf("%%LocalAppData%% is not defined")
+			return
+		}
+		result = filepath.Join(base, name, "state")
+	case "darwin", "ios":
+		base, err = os.UserConfigDir()
+		if err != nil {
+			return
+		}
+		result = filepath.Join(base, name, "state")
+	default:
+		var home string
+		home, err = os.UserHomeDir()
+		if err != nil {
+			return
+		}
+		result = filepath.Join(home, ".local", "state", name)
+	}
+	return
+}
+
+// migration describes a file or directory that was stored in the configuration directory by older versions of the
+// tool and that now should be in the state directory.
+type migration struct {
+	old string
+	new string
+}
+
+// migrations is the list of files and directories that need to be moved.
+var migrations = []migration{
+	{
+		old: "session.json",
+		new: "session.json",
+	},
+	{
+		old: "snapshots",
+		new: "snapshots",
+	},
+}
+
+// Migrate moves the files created by older versions of the tool to the directories where they are expected now. It
+// does nothing if there are no such files, so it is safe to call it every time that the tool starts.
+func Migrate() error {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return err
+	}
+	stateDir, err := StateDir()
+	if err != nil {
+		return err
+	}
+	for _, item := range migrations {
+		oldPath := filepath.Join(configDir, item.old)
+		newPath := filepath.Join(stateDir, item.new)
+		if oldPath == newPath {
+			continue
+		}
+		_, err = os.Stat(oldPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check if '%s' exists: %v", oldPath, err)
+		}
+		_, err = os.Stat(newPath)
+		if err == nil {
+			// Don't overwrite files that have already been created in the new location.
+			continue
+		}
+		err = os.MkdirAll(filepath.Dir(newPath), os.FileMode(0755))
+		if err != nil {
+			return fmt.Errorf("failed to create directory %s: %v", filepath.Dir(newPath), err)
+		}
+		err = os.Rename(oldPath, newPath)
+		if err != nil {
+			return fmt.Errorf("failed to move '%s' to '%s': %v", oldPath, newPath, err)
+		}
+	}
+	return nil
+}
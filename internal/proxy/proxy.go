@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package proxy contains the functions used to connect to the server through HTTP, HTTPS or SOCKS5 proxies.
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+	xproxy "golang.org/x/net/proxy"
+)
+
+// Schemes are the schemes of the supported proxy URLs.
+var Schemes = []string{"http", "https", "socks5", "socks5h"}
+
+// Parse parses and checks a proxy URL, like 'http://proxy.example.com:3128' or 'socks5://localhost:1080'.
+func Parse(text string) (result *url.URL, err error) {
+	result, err = url.Parse(text)
+	if err != nil {
+		err = fmt.Errorf("proxy URL '%s' isn't valid: %v", text, err)
+		return
+	}
+	if !slices.Contains(Schemes, result.Scheme) || result.Host == "" {
+		err = fmt.Errorf(
+			"proxy URL '%s' isn't valid, it should be like 'http://host:port', and the scheme should be "+
+				"one of '%s'",
+			text, strings.Join(Schemes, "', '"),
+		)
+		result = nil
+	}
+	return
+}
+
+// Find returns the proxy that should be used to connect to the given address, in 'host:port' format. If a proxy URL
+// is configured it is used, otherwise the 'HTTPS_PROXY' environment variable is used. In both cases the 'NO_PROXY'
+// environment variable is honored. It returns nil if no proxy should be used.
+func Find(configured string, address string) (result *url.URL, err error) {
+	config := httpproxy.FromEnvironment()
+	if configured != "" {
+		config.HTTPSProxy = configured
+	}
+	if config.HTTPSProxy == "" {
+		return
+	}
+	result, err = config.ProxyFunc()(&url.URL{
+		Scheme: "https",
+		Host:   address,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find proxy for '%s': %v", address, err)
+		return
+	}
+	if result != nil && !slices.Contains(Schemes, result.Scheme) {
+		err = fmt.Errorf(
+			"proxy URL '%s' isn't supported, the scheme should be one of '%s'",
+			result.Redacted(), strings.Join(Schemes, "', '"),
+		)
+		result = nil
+	}
+	return
+}
+
+// Dialer returns a function that opens connections through the given proxy, suitable for the 'grpc.WithContextDialer'
+// option. HTTP and HTTPS proxies are used with the 'CONNECT' method, and SOCKS5 proxies with the SOCKS5 protocol.
+func Dialer(proxy *url.URL) (result func(ctx context.Context, address string) (net.Conn, error), err error) {
+	switch proxy.Scheme {
+	case "socks5", "socks5h":
+		var dialer xproxy.Dialer
+		dialer, err = xproxy.FromURL(proxy, &net.Dialer{})
+		if err != nil {
+			err = fmt.Errorf("failed to create SOCKS5 dialer for proxy '%s': %v", proxy.Redacted(), err)
+			return
+		}
+		contextDialer, ok := dialer.(xproxy.ContextDialer)
+		if !ok {
+			err = fmt.Errorf("SOCKS5 dialer for proxy '%s' doesn't support contexts", proxy.Redacted())
+			return
+		}
+		result = func(ctx context.Context, address string) (net.Conn, error) {
+			return contextDialer.DialContext(ctx, "tcp", address)
+		}
+	default:
+		result = func(ctx context.Context, address string) (net.Conn, error) {
+			return connect(ctx, proxy, address)
+		}
+	}
+	return
+}
+
+// connect opens a connection to the HTTP or HTTPS proxy and asks it to create a tunnel to the given address using the
+// 'CONNECT' method.
+func connect(ctx context.Context, proxy *url.URL, address string) (result net.Conn, err error) {
+	host := proxy.Host
+	if proxy.Port() == "" {
+		port := "80"
+		if proxy.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(proxy.Hostname(), port)
+	}
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		err = fmt.Errorf("failed to connect to proxy '%s': %w", proxy.Redacted(), err)
+		return
+	}
+	if proxy.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName: proxy.Hostname(),
+		})
+		err = tlsConn.HandshakeContext(ctx)
+		if err != nil {
+			conn.Close()
+			err = fmt.Errorf("failed to establish TLS connection to proxy '%s': %w", proxy.Redacted(), err)
+			return
+		}
+		conn = tlsConn
+	}
+
+	// Abort the handshake with the proxy if the context is cancelled:
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	// Send the request:
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL: &url.URL{
+			Opaque: address,
+		},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		credentials := proxy.User.Username() + ":" + password
+		request.Header.Set(
+			"Proxy-Authorization",
+			"Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)),
+		)
+	}
+	err = request.Write(conn)
+	if err != nil {
+		conn.Close()
+		err = fmt.Errorf("failed to send request to proxy '%s': %w", proxy.Redacted(), err)
+		return
+	}
+
+	// Check the response:
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		conn.Close()
+		err = fmt.Errorf("failed to read response from proxy '%s': %w", proxy.Redacted(), err)
+		return
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		conn.Close()
+		err = fmt.Errorf(
+			"proxy '%s' refused to connect to '%s': %s",
+			proxy.Redacted(), address, response.Status,
+		)
+		return
+	}
+
+	// The proxy shouldn't send anything after the response until the tunnel is used, but if it does then the data
+	// is in the buffer of the reader and needs to be returned before reading from the connection again:
+	if reader.Buffered() > 0 {
+		result = &bufferedConn{
+			Conn:   conn,
+			reader: reader,
+		}
+		return
+	}
+	result = conn
+	return
+}
+
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(data []byte) (int, error) {
+	return c.reader.Read(data)
+}
@@ -0,0 +1,280 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/innabox/fulfillment-cli/internal/paths"
+)
+
+// Session is the type used to store the state of a session that is being recorded.
+type Session struct {
+	Output   string     `json:"output,omitempty"`
+	Started  time.Time  `json:"started,omitempty"`
+	Commands [][]string `json:"commands,omitempty"`
+}
+
+// Secret describes a command line flag, or a setting of the 'config set' command, whose value must not be written to
+// the generated script. The value is replaced by a reference to the given environment variable.
+type Secret struct {
+	Flag     string
+	Setting  string
+	Variable string
+}
+
+// Secrets is the list of flags and settings whose values are replaced by placeholders in the generated scripts.
+var Secrets = []Secret{
+	{
+		Flag:     "--token",
+		Variable: "FULFILLMENT_CLI_TOKEN",
+	},
+	{
+		Flag:     "--proxy",
+		Setting:  "proxy",
+		Variable: "FULFILLMENT_CLI_PROXY",
+	},
+	{
+		Flag:     "--client-id",
+		Setting:  "oidc.client_id",
+		Variable: "FULFILLMENT_CLI_CLIENT_ID",
+	},
+	{
+		Flag:     "--from-kube-secret",
+		Variable: "FULFILLMENT_CLI_KUBE_SECRET",
+	},
+}
+
+// Load loads the session that is currently being recorded. It returns nil if there is no such session.
+func Load() (session *Session, err error) {
+	file, err := Location()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read session file '%s': %v", file, err)
+		return
+	}
+	session = &Session{}
+	err = json.Unmarshal(data, session)
+	if err != nil {
+		err = fmt.Errorf("failed to parse session file '%s': %v", file, err)
+		return
+	}
+	return
+}
+
+// Save saves the given session.
+func Save(session *Session) error {
+	file, err := Location()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %v", err)
+	}
+	dir := filepath.Dir(file)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %v", dir, err)
+	}
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write file '%s': %v", file, err)
+	}
+	return nil
+}
+
+// Delete discards the session that is currently being recorded.
+func Delete() error {
+	file, err := Location()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(file)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete session file '%s': %v", file, err)
+	}
+	return nil
+}
+
+// Location returns the location of the file that stores the session that is being recorded.
+func Location() (result string, err error) {
+	stateDir, err := paths.StateDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(stateDir, "session.json")
+	return
+}
+
+// Append adds a command to the session, replacing the values of secret flags and settings with placeholders.
+func (s *Session) Append(args []string) {
+	command := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		secret, inline := findSecret(arg)
+		if secret == nil {
+			command = append(command, arg)
+			continue
+		}
+		placeholder := fmt.Sprintf("${%s}", secret.Variable)
+		if inline {
+			command = append(command, secret.Flag+"="+placeholder)
+			continue
+		}
+		command = append(command, arg)
+		if i+1 < len(args) {
+			command = append(command, placeholder)
+			i++
+		}
+	}
+	maskSetting(command)
+	s.Commands = append(s.Commands, command)
+}
+
+// maskSetting replaces the value of the 'config set KEY VALUE' command with a placeholder if the setting is secret.
+// Flags are skipped when looking for the positional arguments, but their values aren't, so the words 'config' and
+// 'set' are searched anywhere in the positional arguments.
+func maskSetting(command []string) {
+	var positional []int
+	for i, arg := range command {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, i)
+		}
+	}
+	for i := 0; i+3 < len(positional); i++ {
+		if command[positional[i]] != "config" || command[positional[i+1]] != "set" {
+			continue
+		}
+		key := command[positional[i+2]]
+		for _, secret := range Secrets {
+			if secret.Setting != "" && secret.Setting == key {
+				command[positional[i+3]] = fmt.Sprintf("${%s}", secret.Variable)
+				break
+			}
+		}
+		return
+	}
+}
+
+// Script generates a shell script that repeats the commands of the session.
+func (s *Session) Script(binary string) string {
+	buffer := &strings.Builder{}
+	fmt.Fprintf(buffer, "#!/bin/sh\n")
+	fmt.Fprintf(buffer, "\n")
+	fmt.Fprintf(buffer, "# Generated by '%s record' from the session started at %s.\n", binary,
+		s.Started.Format(time.RFC3339))
+	fmt.Fprintf(buffer, "\n")
+	fmt.Fprintf(buffer, "set -e\n")
+
+	// Check that the variables used as placeholders for secrets are set:
+	variables := s.variables()
+	if len(variables) > 0 {
+		fmt.Fprintf(buffer, "\n")
+		for _, variable := range variables {
+			fmt.Fprintf(buffer, ": \"${%s:?must be set}\"\n", variable)
+		}
+	}
+
+	// Add the commands:
+	if len(s.Commands) > 0 {
+		fmt.Fprintf(buffer, "\n")
+	}
+	for _, command := range s.Commands {
+		words := make([]string, len(command)+1)
+		words[0] = binary
+		for i, arg := range command {
+			words[i+1] = quote(arg)
+		}
+		fmt.Fprintf(buffer, "%s\n", strings.Join(words, " "))
+	}
+	return buffer.String()
+}
+
+func (s *Session) variables() []string {
+	var result []string
+	for _, secret := range Secrets {
+		placeholder := fmt.Sprintf("${%s}", secret.Variable)
+		for _, command := range s.Commands {
+			if containsPlaceholder(command, placeholder) {
+				result = append(result, secret.Variable)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func containsPlaceholder(command []string, placeholder string) bool {
+	for _, arg := range command {
+		if strings.Contains(arg, placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+func findSecret(arg string) (result *Secret, inline bool) {
+	for i := range Secrets {
+		secret := &Secrets[i]
+		if arg == secret.Flag {
+			result = secret
+			return
+		}
+		if strings.HasPrefix(arg, secret.Flag+"=") {
+			result = secret
+			inline = true
+			return
+		}
+	}
+	return
+}
+
+// quote quotes the given argument so that it can be safely used in a shell script. Placeholders for secrets are
+// quoted with double quotes so that the shell will expand them.
+func quote(arg string) string {
+	for _, secret := range Secrets {
+		placeholder := fmt.Sprintf("${%s}", secret.Variable)
+		if strings.Contains(arg, placeholder) {
+			return `"` + arg + `"`
+		}
+	}
+	if arg != "" && strings.IndexFunc(arg, isUnsafe) == -1 {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+}
+
+func isUnsafe(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	case strings.ContainsRune("-_./:=,@%+", r):
+		return false
+	default:
+		return true
+	}
+}
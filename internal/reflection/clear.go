@@ -0,0 +1,32 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package reflection
+
+import (
+	"google.golang.org/protobuf/proto"
+)
+
+// Clear clears the top level fields of the message that have the given names, for example 'status' or 'metadata'
+// to remove the values assigned by the server before creating the object again. Names of fields that the message
+// doesn't have are ignored.
+func Clear(message proto.Message, names ...string) {
+	reflected := message.ProtoReflect()
+	descriptor := reflected.Descriptor()
+	for _, name := range names {
+		field := findField(descriptor, name)
+		if field != nil {
+			reflected.Clear(field)
+		}
+	}
+}
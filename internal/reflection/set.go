@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package reflection contains functions that use the protocol buffers descriptors to manipulate API objects without
+// knowing their types in advance.
+package reflection
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Set sets the field of the message identified by the given path. The path is a dot separated list of field names,
+// using the names from the protocol buffers definitions, for example 'spec.template_id'. Intermediate messages are
+// created when needed. For map fields the segment that follows the name of the field is the key. For repeated fields
+// the value is added to the end of the list. The text is converted to the type of the field.
+func Set(message proto.Message, path string, text string) error {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("path '%s' isn't valid, it contains empty segments", path)
+		}
+	}
+	return set(message.ProtoReflect(), path, segments, text)
+}
+
+func set(message protoreflect.Message, path string, segments []string, text string) error {
+	descriptor := message.Descriptor()
+	field := findField(descriptor, segments[0])
+	if field == nil {
+		return fmt.Errorf(
+			"type '%s' doesn't have a field named '%s', check path '%s'",
+			descriptor.FullName(), segments[0], path,
+		)
+	}
+	rest := segments[1:]
+	switch {
+	case field.IsMap():
+		if len(rest) == 0 {
+			return fmt.Errorf("path '%s' should contain the key for map field '%s'", path, field.Name())
+		}
+		key, err := parseScalar(field.MapKey(), rest[0])
+		if err != nil {
+			return fmt.Errorf("invalid key for map field '%s' of path '%s': %w", field.Name(), path, err)
+		}
+		if isAny(field.MapValue()) {
+			return fmt.Errorf(
+				"values of map field '%s' of path '%s' have type '%s' and can't be set from text",
+				field.Name(), path, anyName,
+			)
+		}
+		entries := message.Mutable(field).Map()
+		if field.MapValue().Message() != nil {
+			if len(rest) == 1 {
+				return fmt.Errorf(
+					"path '%s' should select a field of the values of map field '%s'",
+					path, field.Name(),
+				)
+			}
+			return set(entries.Mutable(key.MapKey()).Message(), path, rest[1:], text)
+		}
+		if len(rest) > 1 {
+			return fmt.Errorf("values of map field '%s' of path '%s' don't have fields", field.Name(), path)
+		}
+		value, err := parseScalar(field.MapValue(), text)
+		if err != nil {
+			return fmt.Errorf("invalid value for path '%s': %w", path, err)
+		}
+		entries.Set(key.MapKey(), value)
+	case field.IsList():
+		if field.Message() != nil {
+			return fmt.Errorf("repeated message field '%s' of path '%s' can't be set from text", field.Name(), path)
+		}
+		if len(rest) > 0 {
+			return fmt.Errorf("repeated field '%s' of path '%s' doesn't have fields", field.Name(), path)
+		}
+		value, err := parseScalar(field, text)
+		if err != nil {
+			return fmt.Errorf("invalid value for path '%s': %w", path, err)
+		}
+		message.Mutable(field).List().Append(value)
+	case isAny(field):
+		return fmt.Errorf(
+			"field '%s' of path '%s' has type '%s' and can't be set from text",
+			field.Name(), path, anyName,
+		)
+	case field.Message() != nil:
+		if len(rest) == 0 {
+			return fmt.Errorf("path '%s' should select a field of message field '%s'", path, field.Name())
+		}
+		return set(message.Mutable(field).Message(), path, rest, text)
+	default:
+		if len(rest) > 0 {
+			return fmt.Errorf("field '%s' of path '%s' doesn't have fields", field.Name(), path)
+		}
+		value, err := parseScalar(field, text)
+		if err != nil {
+			return fmt.Errorf("invalid value for path '%s': %w", path, err)
+		}
+		message.Set(field, value)
+	}
+	return nil
+}
+
+// anyName is the name of the type of fields that can contain any kind of message. These can't be set from text
+// because the type of the message isn't known.
+const anyName = "google.protobuf.Any"
+
+func isAny(field protoreflect.FieldDescriptor) bool {
+	return field.Message() != nil && field.Message().FullName() == anyName
+}
+
+// findField finds a field by its protocol buffers name or by its JSON name.
+func findField(descriptor protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := descriptor.Fields()
+	result := fields.ByName(protoreflect.Name(name))
+	if result == nil {
+		result = fields.ByJSONName(name)
+	}
+	return result
+}
+
+// parseScalar converts the given text into a value of the type of the field.
+func parseScalar(field protoreflect.FieldDescriptor, text string) (result protoreflect.Value, err error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		var value bool
+		value, err = strconv.ParseBool(text)
+		result = protoreflect.ValueOfBool(value)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var value int64
+		value, err = strconv.ParseInt(text, 10, 32)
+		result = protoreflect.ValueOfInt32(int32(value))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var value int64
+		value, err = strconv.ParseInt(text, 10, 64)
+		result = protoreflect.ValueOfInt64(value)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var value uint64
+		value, err = strconv.ParseUint(text, 10, 32)
+		result = protoreflect.ValueOfUint32(uint32(value))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var value uint64
+		value, err = strconv.ParseUint(text, 10, 64)
+		result = protoreflect.ValueOfUint64(value)
+	case protoreflect.FloatKind:
+		var value float64
+		value, err = strconv.ParseFloat(text, 32)
+		result = protoreflect.ValueOfFloat32(float32(value))
+	case protoreflect.DoubleKind:
+		var value float64
+		value, err = strconv.ParseFloat(text, 64)
+		result = protoreflect.ValueOfFloat64(value)
+	case protoreflect.StringKind:
+		result = protoreflect.ValueOfString(text)
+	case protoreflect.BytesKind:
+		result = protoreflect.ValueOfBytes([]byte(text))
+	case protoreflect.EnumKind:
+		var number protoreflect.EnumNumber
+		number, err = parseEnum(field.Enum(), text)
+		result = protoreflect.ValueOfEnum(number)
+	default:
+		err = fmt.Errorf("fields of kind '%s' can't be set from text", field.Kind())
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to convert '%s' to the type of field '%s': %w", text, field.Name(), err)
+	}
+	return
+}
+
+// parseEnum finds the enum value that corresponds to the given text. The text can be the complete name of the value,
+// like 'CLUSTER_STATE_READY', the name without the prefix, like 'READY', or the number.
+func parseEnum(descriptor protoreflect.EnumDescriptor, text string) (result protoreflect.EnumNumber, err error) {
+	values := descriptor.Values()
+	upper := strings.ToUpper(text)
+	value := values.ByName(protoreflect.Name(upper))
+	if value == nil {
+		for i := 0; i < values.Len(); i++ {
+			candidate := values.Get(i)
+			if strings.HasSuffix(string(candidate.Name()), "_"+upper) {
+				value = candidate
+				break
+			}
+		}
+	}
+	if value != nil {
+		result = value.Number()
+		return
+	}
+	number, err := strconv.ParseInt(text, 10, 32)
+	if err != nil {
+		err = fmt.Errorf("'%s' isn't a valid value of enum type '%s'", text, descriptor.FullName())
+		return
+	}
+	result = protoreflect.EnumNumber(number)
+	return
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package secrets contains functions to store secrets, like authentication tokens, in the keyring of the operating
+// system: the Secret Service on Linux, the Keychain on macOS and the Credential Manager on Windows.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// StoreFile indicates that secrets are stored in the configuration file. This is the default.
+	StoreFile = "file"
+
+	// StoreKeyring indicates that secrets are stored in the keyring of the operating system.
+	StoreKeyring = "keyring"
+)
+
+// Stores is the list of supported places to store secrets.
+var Stores = []string{
+	StoreFile,
+	StoreKeyring,
+}
+
+// service is the name of the service used to group the secrets of this tool in the keyring.
+const service = "fulfillment-cli"
+
+// Get returns the value of the secret with the given name. It returns an empty string if there is no such secret.
+func Get(name string) (result string, err error) {
+	result, err = keyring.Get(service, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to get secret '%s' from the keyring: %v", name, err)
+	}
+	return
+}
+
+// Set saves the value of the secret with the given name. If the value is empty the secret is deleted.
+func Set(name, value string) error {
+	if value == "" {
+		return Delete(name)
+	}
+	err := keyring.Set(service, name, value)
+	if err != nil {
+		return fmt.Errorf("failed to save secret '%s' to the keyring: %v", name, err)
+	}
+	return nil
+}
+
+// Delete deletes the secret with the given name. It isn't an error if the secret doesn't exist.
+func Delete(name string) error {
+	err := keyring.Delete(service, name)
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("failed to delete secret '%s' from the keyring: %v", name, err)
+	}
+	return nil
+}
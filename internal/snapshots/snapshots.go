@@ -0,0 +1,248 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package snapshots stores local copies of the objects retrieved from the server, so that it is possible to find
+// what changed in an object even if the server doesn't keep a history of revisions.
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/innabox/fulfillment-cli/internal/paths"
+)
+
+// Snapshot is a copy of an object taken at a point in time.
+type Snapshot struct {
+	Time   time.Time
+	Object map[string]any
+}
+
+// Change describes a field that changed between two snapshots.
+type Change struct {
+	Time  time.Time
+	Field string
+	Old   any
+	New   any
+}
+
+// MaxSnapshots is the maximum number of snapshots kept for each object. When a new snapshot is saved the oldest ones
+// are removed, so that the space used, and the time needed to load them, doesn't grow without limit.
+const MaxSnapshots = 50
+
+// Save saves a snapshot of the given object. Nothing is saved if the object didn't change since the last snapshot.
+// Only the last snapshot is read to check that, so the cost doesn't depend on the number of snapshots.
+func Save(kind, id string, message proto.Message) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s '%s': %v", kind, id, err)
+	}
+	object := map[string]any{}
+	err = json.Unmarshal(data, &object)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s '%s': %v", kind, id, err)
+	}
+	dir, err := objectDir(kind, id)
+	if err != nil {
+		return err
+	}
+	times, err := list(dir)
+	if err != nil {
+		return err
+	}
+	if len(times) > 0 {
+		last, err := read(dir, times[len(times)-1])
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(last.Object, object) {
+			return nil
+		}
+	}
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %v", dir, err)
+	}
+	file := filepath.Join(dir, strconv.FormatInt(time.Now().UnixNano(), 10)+".json")
+	err = os.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write file '%s': %v", file, err)
+	}
+
+	// Remove the oldest snapshots, taking into account the one that we just saved:
+	for len(times) >= MaxSnapshots {
+		file = filepath.Join(dir, strconv.FormatInt(times[0], 10)+".json")
+		err = os.Remove(file)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove file '%s': %v", file, err)
+		}
+		times = times[1:]
+	}
+	return nil
+}
+
+// Load loads the snapshots of the given object, sorted by time.
+func Load(kind, id string) (result []*Snapshot, err error) {
+	dir, err := objectDir(kind, id)
+	if err != nil {
+		return
+	}
+	times, err := list(dir)
+	if err != nil {
+		return
+	}
+	result = make([]*Snapshot, len(times))
+	for i, nanos := range times {
+		result[i], err = read(dir, nanos)
+		if err != nil {
+			result = nil
+			return
+		}
+	}
+	return
+}
+
+// list returns the times, in nanoseconds, of the snapshots stored in the given directory, sorted from oldest to
+// newest.
+func list(dir string) (result []int64, err error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read directory '%s': %v", dir, err)
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		nanos, err := strconv.ParseInt(strings.TrimSuffix(name, ".json"), 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, nanos)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return
+}
+
+// read reads the snapshot taken at the given time from the given directory.
+func read(dir string, nanos int64) (result *Snapshot, err error) {
+	file := filepath.Join(dir, strconv.FormatInt(nanos, 10)+".json")
+	data, err := os.ReadFile(file)
+	if err != nil {
+		err = fmt.Errorf("failed to read file '%s': %v", file, err)
+		return
+	}
+	snapshot := &Snapshot{
+		Time:   time.Unix(0, nanos),
+		Object: map[string]any{},
+	}
+	err = json.Unmarshal(data, &snapshot.Object)
+	if err != nil {
+		err = fmt.Errorf("failed to parse file '%s': %v", file, err)
+		return
+	}
+	result = snapshot
+	return
+}
+
+// Location returns the directory where the snapshots are stored.
+func Location() (result string, err error) {
+	stateDir, err := paths.StateDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(stateDir, "snapshots")
+	return
+}
+
+func objectDir(kind, id string) (result string, err error) {
+	dir, err := Location()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(dir, kind, url.PathEscape(id))
+	return
+}
+
+// Diff calculates the fields that changed between two snapshots. The time of the changes is the time of the new
+// snapshot.
+func Diff(old, new *Snapshot) []Change {
+	oldFields := map[string]any{}
+	flatten("", old.Object, oldFields)
+	newFields := map[string]any{}
+	flatten("", new.Object, newFields)
+	var paths []string
+	for path := range oldFields {
+		paths = append(paths, path)
+	}
+	for path := range newFields {
+		if _, ok := oldFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	var result []Change
+	for _, path := range paths {
+		oldValue := oldFields[path]
+		newValue := newFields[path]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		result = append(result, Change{
+			Time:  new.Time,
+			Field: path,
+			Old:   oldValue,
+			New:   newValue,
+		})
+	}
+	return result
+}
+
+// flatten converts the given value into a set of fields where the keys are paths like 'status.conditions[0].type'
+// and the values are the scalar values of those paths.
+func flatten(prefix string, value any, fields map[string]any) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, item := range typed {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, item, fields)
+		}
+	case []any:
+		for i, item := range typed {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), item, fields)
+		}
+	default:
+		fields[prefix] = value
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package snapshots
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSaveSkipsUnchanged(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	object, err := structpb.NewStruct(map[string]any{"state": "READY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		err = Save("cluster", "123", object)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	list, err := Load("cluster", "123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected one snapshot, but got %d", len(list))
+	}
+}
+
+func TestSavePrunesOldest(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	for i := 0; i < MaxSnapshots+10; i++ {
+		object, err := structpb.NewStruct(map[string]any{"generation": float64(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = Save("cluster", "123", object)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	list, err := Load("cluster", "123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != MaxSnapshots {
+		t.Fatalf("expected %d snapshots, but got %d", MaxSnapshots, len(list))
+	}
+	first := list[0].Object["generation"]
+	last := list[len(list)-1].Object["generation"]
+	if first != float64(10) || last != float64(MaxSnapshots+9) {
+		t.Fatalf("expected generations from 10 to %d, but got from %v to %v", MaxSnapshots+9, first, last)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tracker remembers the last version of a set of objects in memory, so that it is possible to find what fields
+// changed when a new version is received, for example when watching events.
+type Tracker struct {
+	last map[string]*Snapshot
+}
+
+// NewTracker creates a tracker that doesn't know any object yet.
+func NewTracker() *Tracker {
+	return &Tracker{
+		last: map[string]*Snapshot{},
+	}
+}
+
+// Update remembers the new version of the object with the given identifier, and returns the paths of the fields that
+// changed since the previous version, for example 'status.state'. The result is empty if there was no previous version.
+func (t *Tracker) Update(id string, message proto.Message) (result []string, err error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		err = fmt.Errorf("failed to marshal object '%s': %v", id, err)
+		return
+	}
+	current := &Snapshot{
+		Time:   time.Now(),
+		Object: map[string]any{},
+	}
+	err = json.Unmarshal(data, &current.Object)
+	if err != nil {
+		err = fmt.Errorf("failed to unmarshal object '%s': %v", id, err)
+		return
+	}
+	previous, ok := t.last[id]
+	t.last[id] = current
+	if !ok {
+		return
+	}
+	for _, change := range Diff(previous, current) {
+		result = append(result, change.Field)
+	}
+	return
+}
+
+// Forget discards the last version of the object with the given identifier, for example when it has been deleted.
+func (t *Tracker) Forget(id string) {
+	delete(t.last, id)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package tables loads the definitions of the tables used by the 'get' commands that users can put in the 'tables'
+// sub-directory of the configuration directory. The name of each file is the type of object followed by the '.yaml'
+// extension, for example 'cluster.yaml', and the content is the list of columns:
+//
+//	columns:
+//	- header: ID
+//	- header: STATE
+//	- header: API
+//	  expression: cluster.status.api_url
+//	  max: 40
+//	- header: CONDITIONS
+//	  expression: size(cluster.status.conditions)
+//	  align: right
+//	  wide: true
+//
+// Columns without an expression are selected from the default columns of the table, so a definition can extend the
+// default table adding new columns, or replace it completely.
+//
+// Users can also add columns in the 'columns' section of the configuration file. Those are appended to the table
+// defined in the file, or to the default columns if there is no such file.
+package tables
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/expressions"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/paths"
+)
+
+// table is the content of a table definition file.
+type table struct {
+	Columns []column `json:"columns"`
+}
+
+// column is the definition of a column inside a table definition file.
+type column struct {
+	Header     string `json:"header"`
+	Expression string `json:"expression,omitempty"`
+	Width      int    `json:"width,omitempty"`
+	Max        int    `json:"max,omitempty"`
+	Align      string `json:"align,omitempty"`
+	Wide       bool   `json:"wide,omitempty"`
+}
+
+// Columns loads the definition of the table for the given type of object, if it exists, adds the extra columns from
+// the configuration file, and compiles the result. The name and message are used to compile the expressions as
+// described in the expressions.CompileDefinitions function. It returns nil if the user didn't define a table or extra
+// columns for that type.
+func Columns(kind string, name string, message proto.Message, defaults []output.Column,
+	extra []config.ColumnConfig) (result *expressions.Columns, err error) {
+	// Load the table file, if it exists:
+	dir, err := Location()
+	if err != nil {
+		return
+	}
+	file := filepath.Join(dir, kind+".yaml")
+	data, err := os.ReadFile(file)
+	exists := err == nil
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read table file '%s': %v", file, err)
+		return
+	}
+	if !exists && len(extra) == 0 {
+		return
+	}
+	var definitions []expressions.Definition
+	if exists {
+		var content table
+		err = yaml.UnmarshalStrict(data, &content)
+		if err != nil {
+			err = fmt.Errorf("failed to parse table file '%s': %v", file, err)
+			return
+		}
+		for i, column := range content.Columns {
+			var definition expressions.Definition
+			definition, err = makeDefinition(column, i, fmt.Sprintf("table file '%s'", file))
+			if err != nil {
+				return
+			}
+			definitions = append(definitions, definition)
+		}
+	} else {
+		for _, column := range defaults {
+			definitions = append(definitions, expressions.Definition{
+				Header: column.Header,
+				Wide:   column.Wide,
+			})
+		}
+	}
+
+	// Add the columns from the configuration file. Note that these always need an expression, otherwise they would
+	// be just copies of the default columns.
+	for i, item := range extra {
+		if item.Value == "" {
+			err = fmt.Errorf("column %d for '%s' in the configuration file doesn't have a value", i+1, kind)
+			return
+		}
+		var definition expressions.Definition
+		definition, err = makeDefinition(column{
+			Header:     item.Header,
+			Expression: item.Value,
+			Width:      item.Width,
+			Max:        item.Max,
+			Align:      item.Align,
+			Wide:       item.Wide,
+		}, i, fmt.Sprintf("'%s' in the configuration file", kind))
+		if err != nil {
+			return
+		}
+		definitions = append(definitions, definition)
+	}
+
+	result, err = expressions.CompileDefinitions(name, message, definitions, defaults)
+	if err != nil {
+		err = fmt.Errorf("failed to compile table for '%s': %v", kind, err)
+	}
+	return
+}
+
+// makeDefinition checks a column and converts it into the definition used by the expressions package. The index and
+// the description of the place where the column was defined are used for the error messages.
+func makeDefinition(column column, index int, where string) (result expressions.Definition, err error) {
+	if column.Header == "" {
+		err = fmt.Errorf("column %d of %s doesn't have a header", index+1, where)
+		return
+	}
+	result = expressions.Definition{
+		Header:     column.Header,
+		Expression: column.Expression,
+		Width:      column.Width,
+		Max:        column.Max,
+		Wide:       column.Wide,
+	}
+	switch column.Align {
+	case "", "left":
+		result.Align = output.AlignLeft
+	case "right":
+		result.Align = output.AlignRight
+	default:
+		err = fmt.Errorf(
+			"alignment '%s' of column '%s' of %s isn't valid, it should be 'left' or 'right'",
+			column.Align, column.Header, where,
+		)
+	}
+	return
+}
+
+// Location returns the directory where the table definition files are stored.
+func Location() (result string, err error) {
+	configDir, err := paths.ConfigDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(configDir, "tables")
+	return
+}
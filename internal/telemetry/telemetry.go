@@ -0,0 +1,275 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package telemetry collects anonymous usage metrics and submits them periodically to the endpoint configured by the
+// user. Nothing is collected unless the user explicitly enables it with the 'telemetry enable' command.
+//
+// The metrics only contain the names of the commands, how many times they were executed, the classes of the errors
+// that they returned and their latency. They never contain arguments, flags, addresses, identifiers or tokens.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/innabox/fulfillment-cli/internal/config"
+	"github.com/innabox/fulfillment-cli/internal/paths"
+)
+
+// SubmitInterval is the minimum time between submissions of the metrics.
+const SubmitInterval = 24 * time.Hour
+
+// submitTimeout is the maximum time that we wait for the endpoint to accept the metrics, so that a slow endpoint
+// doesn't slow down the commands.
+const submitTimeout = 2 * time.Second
+
+// maxSamples is the maximum number of latency samples kept for each command.
+const maxSamples = 100
+
+// Data contains the metrics collected since the last submission.
+type Data struct {
+	Since    time.Time               `json:"since"`
+	Commands map[string]*CommandData `json:"commands,omitempty"`
+}
+
+// CommandData contains the metrics of one command.
+type CommandData struct {
+	Count     int            `json:"count"`
+	Errors    map[string]int `json:"errors,omitempty"`
+	Latencies []int64        `json:"latencies,omitempty"`
+}
+
+// Report is the document that is sent to the endpoint.
+type Report struct {
+	Since    time.Time       `json:"since"`
+	Until    time.Time       `json:"until"`
+	Commands []CommandReport `json:"commands"`
+}
+
+// CommandReport contains the metrics of one command, as sent to the endpoint.
+type CommandReport struct {
+	Command   string         `json:"command"`
+	Count     int            `json:"count"`
+	Errors    map[string]int `json:"errors,omitempty"`
+	LatencyMs Percentiles    `json:"latency_ms"`
+}
+
+// Percentiles contains the 50, 90 and 99 percentiles of the latency of a command, in milliseconds.
+type Percentiles struct {
+	P50 int64 `json:"p50"`
+	P90 int64 `json:"p90"`
+	P99 int64 `json:"p99"`
+}
+
+// Record adds the result of the execution of a command to the metrics, and submits them if the last submission is
+// older than the submit interval. It does nothing if telemetry isn't enabled. Errors are ignored, because problems
+// collecting or submitting metrics should never affect the user.
+func Record(ctx context.Context, command string, latency time.Duration, err error) {
+	cfg, loadErr := config.LoadSettings()
+	if loadErr != nil || cfg.Telemetry == nil || !cfg.Telemetry.Enabled {
+		return
+	}
+	data, loadErr := Load()
+	if loadErr != nil {
+		return
+	}
+	commandData := data.Commands[command]
+	if commandData == nil {
+		commandData = &CommandData{}
+		data.Commands[command] = commandData
+	}
+	commandData.Count++
+	if err != nil {
+		if commandData.Errors == nil {
+			commandData.Errors = map[string]int{}
+		}
+		commandData.Errors[errorClass(err)]++
+	}
+	commandData.Latencies = append(commandData.Latencies, latency.Milliseconds())
+	if len(commandData.Latencies) > maxSamples {
+		commandData.Latencies = commandData.Latencies[len(commandData.Latencies)-maxSamples:]
+	}
+	if cfg.Telemetry.Endpoint != "" && time.Since(data.Since) >= SubmitInterval {
+		submitErr := Submit(ctx, cfg.Telemetry.Endpoint, data)
+		if submitErr == nil {
+			data = &Data{
+				Since:    time.Now(),
+				Commands: map[string]*CommandData{},
+			}
+		}
+	}
+	Save(data)
+}
+
+// Submit sends the given metrics to the endpoint.
+func Submit(ctx context.Context, endpoint string, data *Data) error {
+	body, err := json.Marshal(data.Report())
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, submitTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send report: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("endpoint '%s' responded with status code %d", endpoint, response.StatusCode)
+	}
+	return nil
+}
+
+// Report calculates the report that will be sent to the endpoint.
+func (d *Data) Report() *Report {
+	names := make([]string, 0, len(d.Commands))
+	for name := range d.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := &Report{
+		Since:    d.Since,
+		Until:    time.Now(),
+		Commands: make([]CommandReport, len(names)),
+	}
+	for i, name := range names {
+		commandData := d.Commands[name]
+		result.Commands[i] = CommandReport{
+			Command: name,
+			Count:   commandData.Count,
+			Errors:  commandData.Errors,
+			LatencyMs: Percentiles{
+				P50: percentile(commandData.Latencies, 50),
+				P90: percentile(commandData.Latencies, 90),
+				P99: percentile(commandData.Latencies, 99),
+			},
+		}
+	}
+	return result
+}
+
+// Load loads the metrics collected since the last submission.
+func Load() (data *Data, err error) {
+	file, err := Location()
+	if err != nil {
+		return
+	}
+	data = &Data{
+		Since:    time.Now(),
+		Commands: map[string]*CommandData{},
+	}
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read telemetry file '%s': %v", file, err)
+		return
+	}
+	err = json.Unmarshal(content, data)
+	if err != nil {
+		err = fmt.Errorf("failed to parse telemetry file '%s': %v", file, err)
+		return
+	}
+	if data.Commands == nil {
+		data.Commands = map[string]*CommandData{}
+	}
+	return
+}
+
+// Save saves the collected metrics.
+func Save(data *Data) error {
+	file, err := Location()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry data: %v", err)
+	}
+	dir := filepath.Dir(file)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %v", dir, err)
+	}
+	err = os.WriteFile(file, content, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write file '%s': %v", file, err)
+	}
+	return nil
+}
+
+// Discard removes all the collected metrics.
+func Discard() error {
+	file, err := Location()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(file)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete telemetry file '%s': %v", file, err)
+	}
+	return nil
+}
+
+// Location returns the location of the file that contains the collected metrics.
+func Location() (result string, err error) {
+	stateDir, err := paths.StateDir()
+	if err != nil {
+		return
+	}
+	result = filepath.Join(stateDir, "telemetry.json")
+	return
+}
+
+// errorClass returns the class of the given error. For gRPC errors this is the name of the status code, for example
+// 'Unavailable'. Other errors are all classified as 'Other', because their messages may contain private details.
+func errorClass(err error) string {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.GRPCStatus().Code().String()
+	}
+	return "Other"
+}
+
+func percentile(samples []int64, p int) int64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := make([]int64, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	index := (len(sorted)*p+99)/100 - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
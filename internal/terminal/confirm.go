@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package terminal contains functions to interact with the user via the terminal.
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Confirm writes the given question to the writer and reads the answer from the reader. It returns true only if the
+// answer is 'y' or 'yes', ignoring case. An empty answer, or reaching the end of the input, is a negative answer.
+func Confirm(reader io.Reader, writer io.Writer, question string) (result bool, err error) {
+	fmt.Fprintf(writer, "%s [y/N]: ", question)
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	if err == io.EOF {
+		fmt.Fprintf(writer, "\n")
+		err = nil
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read answer: %v", err)
+		return
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		result = true
+	}
+	return
+}
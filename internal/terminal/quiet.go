@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+)
+
+// quiet indicates if decorative output, like spinners and informational messages, should be suppressed.
+var quiet bool
+
+// SetQuiet enables or disables quiet mode. This is intended for the global '--quiet' flag.
+func SetQuiet(value bool) {
+	quiet = value
+}
+
+// Quiet returns true if quiet mode is enabled.
+func Quiet() bool {
+	return quiet
+}
+
+// Infof writes an informational message to the given file, unless quiet mode is enabled. Results and errors should
+// never be written with this, only messages that the user can do without.
+func Infof(file *os.File, format string, args ...any) {
+	if quiet {
+		return
+	}
+	fmt.Fprintf(file, format, args...)
+}
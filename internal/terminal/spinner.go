@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// spinnerFrames are the characters that are displayed in turn to show that the spinner is alive.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinnerInterval is the time between frames of the spinner.
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner displays a message preceded by an animated character, to show the progress of a long running operation.
+// Nothing is displayed if the file isn't a terminal or if quiet mode is enabled, so it is safe to use it when the
+// output is redirected. All the methods can be called on a nil spinner, and then they do nothing.
+type Spinner struct {
+	file    *os.File
+	lock    sync.Mutex
+	message string
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// StartSpinner creates a spinner that writes to the given file and starts displaying the given message.
+func StartSpinner(file *os.File, message string) *Spinner {
+	result := &Spinner{
+		file:    file,
+		message: message,
+	}
+	if Quiet() || !IsTerminal(file) {
+		return result
+	}
+	result.done = make(chan struct{})
+	result.stopped = make(chan struct{})
+	go result.run()
+	return result
+}
+
+// Update replaces the message displayed by the spinner.
+func (s *Spinner) Update(message string) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.message = message
+}
+
+// Updatef replaces the message displayed by the spinner, using a format and arguments like fmt.Sprintf.
+func (s *Spinner) Updatef(format string, args ...any) {
+	s.Update(fmt.Sprintf(format, args...))
+}
+
+// Stop stops the spinner and erases the line where it was displayed, so that the next output starts in a clean line.
+// Calling it more than once has no effect.
+func (s *Spinner) Stop() {
+	if s == nil || s.done == nil {
+		return
+	}
+	close(s.done)
+	<-s.stopped
+	s.done = nil
+}
+
+func (s *Spinner) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		s.lock.Lock()
+		message := s.message
+		s.lock.Unlock()
+		fmt.Fprintf(s.file, "\r\x1b[K%s %s", spinnerFrames[frame%len(spinnerFrames)], message)
+		select {
+		case <-s.done:
+			fmt.Fprintf(s.file, "\r\x1b[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package terminal
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// IsTerminal returns true if the given file is a terminal.
+func IsTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
+
+// Colors returns true if the output written to the given file can use colors and other styles. That is the case when
+// the file is a terminal, unless the NO_COLOR environment variable is set, as described in https://no-color.org.
+func Colors(file *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return IsTerminal(file)
+}
+
+// Dim returns the text so that it will be displayed with reduced intensity if the given file supports it.
+func Dim(file *os.File, text string) string {
+	if !Colors(file) {
+		return text
+	}
+	return "\x1b[2m" + text + "\x1b[0m"
+}
+
+// Bold returns the text so that it will be displayed with increased intensity if the given file supports it.
+func Bold(file *os.File, text string) string {
+	if !Colors(file) {
+		return text
+	}
+	return "\x1b[1m" + text + "\x1b[0m"
+}
+
+// Yellow returns the text so that it will be displayed in yellow if the given file supports it. This is intended for
+// warnings.
+func Yellow(file *os.File, text string) string {
+	if !Colors(file) {
+		return text
+	}
+	return "\x1b[33m" + text + "\x1b[0m"
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package version contains the information about the version of the tool. The values can be set when building it,
+// for example:
+//
+//	go build -ldflags "-X github.com/innabox/fulfillment-cli/internal/version.Version=1.2.3"
+//
+// When they aren't set they are taken from the build information that the Go compiler adds to the binary, if
+// available.
+package version
+
+import (
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the tool, for example '1.2.3'.
+var Version string
+
+// Commit is the identifier of the git commit used to build the tool.
+var Commit string
+
+// Date is the date when the tool was built, or the date of the commit if the build date isn't available.
+var Date string
+
+func init() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	if Version == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		Version = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if Commit == "" {
+				Commit = setting.Value
+			}
+		case "vcs.time":
+			if Date == "" {
+				Date = setting.Value
+			}
+		case "vcs.modified":
+			modified, _ := strconv.ParseBool(setting.Value)
+			if modified && Commit != "" && !strings.HasSuffix(Commit, "-dirty") {
+				Commit += "-dirty"
+			}
+		}
+	}
+}
+
+// Major returns the major version number of the given version, for example '1' for 'v1.2.3'. It returns an empty
+// string if the version doesn't have that format.
+func Major(version string) string {
+	version = strings.TrimPrefix(version, "v")
+	major, _, _ := strings.Cut(version, ".")
+	_, err := strconv.Atoi(major)
+	if err != nil {
+		return ""
+	}
+	return major
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package warnings collects the warnings sent by the server while a command runs, so that they can be displayed
+// together, and only once, when the command finishes.
+package warnings
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/innabox/fulfillment-cli/internal/terminal"
+)
+
+var (
+	lock      sync.Mutex
+	collected []string
+	seen      = map[string]bool{}
+)
+
+// Add adds a warning. Warnings that have already been added are ignored, as the server will usually send the same
+// warning in the response to all the calls to the same method.
+func Add(warning string) {
+	lock.Lock()
+	defer lock.Unlock()
+	if seen[warning] {
+		return
+	}
+	seen[warning] = true
+	collected = append(collected, warning)
+}
+
+// Write writes the collected warnings to the given file, highlighted if the file supports it, and then forgets them.
+func Write(file *os.File) {
+	lock.Lock()
+	defer lock.Unlock()
+	for _, warning := range collected {
+		fmt.Fprintf(file, "%s\n", terminal.Yellow(file, "Warning from server: "+warning))
+	}
+	collected = nil
+	seen = map[string]bool{}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package watch contains the code that receives events from the server and that reconnects when the connection is
+// lost.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	eventsv1 "github.com/innabox/fulfillment-cli/internal/api/events/v1"
+)
+
+// Delays used between attempts to reconnect. The delay starts with the minimum and it is doubled after each failed
+// attempt, till it reaches the maximum. These are variables only so that tests can make them shorter.
+var (
+	minDelay = time.Second
+	maxDelay = 30 * time.Second
+)
+
+// Handler is the type of the functions that process events. If the function returns an error the watch stops and
+// returns that error.
+type Handler func(event *eventsv1.Event) error
+
+// Events receives the events that match the given filter and calls the handler for each of them, till the context is
+// cancelled, the server closes the stream or the handler returns an error.
+//
+// If the connection to the server is lost it tries to connect again, waiting between attempts, and it writes a message
+// to the given writer explaining that. Note that the server doesn't support resuming a watch, so the events that happen
+// while the connection is lost aren't received.
+func Events(ctx context.Context, conn *grpc.ClientConn, filter string, writer io.Writer, handler Handler) error {
+	return events(ctx, eventsv1.NewEventsClient(conn), filter, writer, handler)
+}
+
+func events(ctx context.Context, client eventsv1.EventsClient, filter string, writer io.Writer,
+	handler Handler) error {
+	delay := minDelay
+	connected := false
+	for {
+		stream, err := client.Watch(ctx, &eventsv1.EventsWatchRequest{
+			Filter: proto.String(filter),
+		})
+		if err == nil {
+			connected = true
+			for {
+				var response *eventsv1.EventsWatchResponse
+				response, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				delay = minDelay
+				err = handler(response.Event)
+				if err != nil {
+					return err
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ctx.Err() != nil || !transient(err) {
+				return fmt.Errorf("failed to receive event: %w", err)
+			}
+		} else if !connected || ctx.Err() != nil || !transient(err) {
+			// Failing to start the first watch isn't retried, as most likely the server isn't reachable
+			// at all. Failures while reconnecting are retried like lost connections.
+			return fmt.Errorf("failed to start watch: %w", err)
+		}
+		fmt.Fprintf(
+			writer,
+			"Lost connection to the server, reconnecting in %s, events that happen in the meantime will "+
+				"not be displayed\n",
+			delay,
+		)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(2*delay, maxDelay)
+	}
+}
+
+// transient checks if the error is one of those that happen when the connection is lost, so that it makes sense to
+// try to connect again.
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	eventsv1 "github.com/innabox/fulfillment-cli/internal/api/events/v1"
+)
+
+// attempt describes what the fake client does for one call to the watch method: either fail to start the watch, or
+// return a stream that sends the given events and then fails with the given error.
+type attempt struct {
+	watchErr error
+	events   []string
+	recvErr  error
+}
+
+type fakeClient struct {
+	attempts []attempt
+	calls    int
+}
+
+func (c *fakeClient) Watch(ctx context.Context, in *eventsv1.EventsWatchRequest,
+	opts ...grpc.CallOption) (grpc.ServerStreamingClient[eventsv1.EventsWatchResponse], error) {
+	if c.calls >= len(c.attempts) {
+		return nil, status.Error(codes.FailedPrecondition, "no more attempts")
+	}
+	attempt := c.attempts[c.calls]
+	c.calls++
+	if attempt.watchErr != nil {
+		return nil, attempt.watchErr
+	}
+	return &fakeStream{
+		events: attempt.events,
+		err:    attempt.recvErr,
+	}, nil
+}
+
+type fakeStream struct {
+	grpc.ClientStream
+	events []string
+	err    error
+}
+
+func (s *fakeStream) Recv() (response *eventsv1.EventsWatchResponse, err error) {
+	if len(s.events) == 0 {
+		err = s.err
+		return
+	}
+	response = &eventsv1.EventsWatchResponse{
+		Event: &eventsv1.Event{
+			Id: s.events[0],
+		},
+	}
+	s.events = s.events[1:]
+	return
+}
+
+func TestEvents(t *testing.T) {
+	minDelay = time.Millisecond
+	maxDelay = 4 * time.Millisecond
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	tests := []struct {
+		name     string
+		attempts []attempt
+		events   []string
+		messages int
+		err      codes.Code
+	}{
+		{
+			name: "Server closes the stream",
+			attempts: []attempt{
+				{events: []string{"1", "2"}, recvErr: io.EOF},
+			},
+			events: []string{"1", "2"},
+		},
+		{
+			name: "Reconnects after losing the connection",
+			attempts: []attempt{
+				{events: []string{"1"}, recvErr: unavailable},
+				{events: []string{"2"}, recvErr: io.EOF},
+			},
+			events:   []string{"1", "2"},
+			messages: 1,
+		},
+		{
+			name: "Retries while the server is down",
+			attempts: []attempt{
+				{events: []string{"1"}, recvErr: unavailable},
+				{watchErr: unavailable},
+				{watchErr: unavailable},
+				{events: []string{}, recvErr: unavailable},
+				{events: []string{"2"}, recvErr: io.EOF},
+			},
+			events:   []string{"1", "2"},
+			messages: 4,
+		},
+		{
+			name: "Stops on non transient receive error",
+			attempts: []attempt{
+				{events: []string{"1"}, recvErr: status.Error(codes.PermissionDenied, "denied")},
+			},
+			events: []string{"1"},
+			err:    codes.PermissionDenied,
+		},
+		{
+			name: "Stops on non transient reconnect error",
+			attempts: []attempt{
+				{events: []string{"1"}, recvErr: unavailable},
+				{watchErr: status.Error(codes.Unauthenticated, "expired")},
+			},
+			events:   []string{"1"},
+			messages: 1,
+			err:      codes.Unauthenticated,
+		},
+		{
+			name: "Doesn't retry the first watch",
+			attempts: []attempt{
+				{watchErr: unavailable},
+			},
+			err: codes.Unavailable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakeClient{
+				attempts: test.attempts,
+			}
+			writer := &bytes.Buffer{}
+			var received []string
+			err := events(context.Background(), client, "", writer, func(event *eventsv1.Event) error {
+				received = append(received, event.Id)
+				return nil
+			})
+			if test.err != codes.OK {
+				if status.Code(err) != test.err {
+					t.Fatalf("expected error with code '%s', but got: %v", test.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(received, test.events) {
+				t.Fatalf("expected events %q, but got %q", test.events, received)
+			}
+			messages := strings.Count(writer.String(), "Lost connection to the server")
+			if messages != test.messages {
+				t.Fatalf("expected %d messages, but got %d: %s", test.messages, messages, writer.String())
+			}
+			if client.calls != len(test.attempts) {
+				t.Fatalf("expected %d calls, but got %d", len(test.attempts), client.calls)
+			}
+		})
+	}
+}
+
+func TestEventsStopsWhenCancelled(t *testing.T) {
+	minDelay = time.Hour
+	maxDelay = time.Hour
+	client := &fakeClient{
+		attempts: []attempt{
+			{events: []string{"1"}, recvErr: status.Error(codes.Unavailable, "connection refused")},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err := events(ctx, client, "", io.Discard, func(event *eventsv1.Event) error {
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected the context to be cancelled, but got: %v", err)
+	}
+}
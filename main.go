@@ -15,21 +15,49 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"os"
+	"time"
 
 	"github.com/innabox/fulfillment-cli/internal/cmd"
+	"github.com/innabox/fulfillment-cli/internal/output"
+	"github.com/innabox/fulfillment-cli/internal/telemetry"
+	"github.com/innabox/fulfillment-cli/internal/warnings"
 )
 
 func main() {
 	// Create a context:
 	ctx := context.Background()
 
-	// Execute the main command:
+	// Expand the aliases and add the default flags defined in the configuration file:
 	root := cmd.Root()
-	err := root.ExecuteContext(ctx)
+	args, err := cmd.ExpandArgs(root, os.Args[1:])
+	if err != nil {
+		cmd.WriteError(root, os.Stderr, err)
+		os.Exit(cmd.ExitCode(err))
+	}
+	root.SetArgs(args)
+
+	// Execute the main command:
+	start := time.Now()
+	executed, err := root.ExecuteContextC(ctx)
+
+	// Save the output to the file requested with the '--output-file' flag, if any:
+	finishErr := output.FinishStdout(err == nil)
+	if err == nil {
+		err = finishErr
+	}
+
+	// Display the warnings sent by the server, if any:
+	warnings.Write(os.Stderr)
+
+	// Record the usage metrics, if the user enabled them:
+	if executed != nil && !cmd.IsCompletionRequest(executed) {
+		telemetry.Record(ctx, executed.CommandPath(), time.Since(start), err)
+	}
+
+	// Report the error, if any:
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(1)
+		cmd.WriteError(root, os.Stderr, err)
+		os.Exit(cmd.ExitCode(err))
 	}
 }